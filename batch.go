@@ -0,0 +1,141 @@
+package ginvalidate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rumis/govalidate/validator"
+)
+
+// BatchPolicy 批量参数中部分元素校验失败时的处理方式
+type BatchPolicy int
+
+const (
+	// BatchRejectAll 任一元素校验失败时整体失败
+	BatchRejectAll BatchPolicy = iota
+	// BatchPartial 返回校验通过的元素，并以 ValidationErrors 返回校验失败的元素
+	BatchPartial
+)
+
+// BindJsonSlice 解析顶层为数组的JSON参数，逐个元素执行校验
+// 校验失败的参数KEY带有元素下标，如 [3].email
+// BatchPartial 时返回的切片与请求中的数组等长，校验失败的元素为nil
+// Content-type:application/json
+func BindJsonSlice(c *gin.Context, rules []validator.Filter, opts ...Option) ([]map[string]interface{}, int32, error) {
+	o := newOptions(opts)
+	body := limitBody(c, o)
+	replay := bufferBody(c, o)
+	items, err := collectJSONSlice(c, o)
+	replay.restore(c)
+	if body != nil && body.err != nil {
+		return nil, 0, body.err
+	}
+	if err != nil {
+		return nil, 0, &ParseError{Source: SourceJSON, Err: err}
+	}
+	return validateSlice(c, items, rules, o)
+}
+
+// BindJsonSliceContext 解析顶层为数组的JSON参数，逐个元素执行校验
+// Content-type:application/json
+func BindJsonSliceContext(c *gin.Context, rules []validator.Filter, opts ...Option) ([]map[string]interface{}, int32, error) {
+	return BindJsonSlice(c, rules, withOptions(opts, WithContext())...)
+}
+
+// BindJsonStructSlice 解析顶层为数组的JSON参数，返回值为对象切片
+// obj 须为切片的指针，BatchPartial 时校验失败的元素为零值
+// Content-type:application/json
+func BindJsonStructSlice(c *gin.Context, rules []validator.Filter, obj interface{}, opts ...Option) (int32, error) {
+	res, errCode, err := BindJsonSlice(c, rules, opts...)
+	if res == nil {
+		return errCode, err
+	}
+	if dErr := mapDecode(res, obj, newOptions(opts).decoder); dErr != nil {
+		return 0, &DecodeError{Err: dErr}
+	}
+	return errCode, err
+}
+
+// BindJsonStructSliceContext 解析顶层为数组的JSON参数，返回值为对象切片
+// Content-type:application/json
+func BindJsonStructSliceContext(c *gin.Context, rules []validator.Filter, obj interface{}, opts ...Option) (int32, error) {
+	return BindJsonStructSlice(c, rules, obj, withOptions(opts, WithContext())...)
+}
+
+// collectJSONSlice 解析顶层为数组的JSON参数，每个元素合并路径参数与header参数
+func collectJSONSlice(c *gin.Context, o *options) ([]map[string]interface{}, error) {
+	defer c.Request.Body.Close()
+	// 解析body
+	decoder := json.NewDecoder(c.Request.Body)
+	decoder.UseNumber()
+	var items []map[string]interface{}
+	err := decoder.Decode(&items)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	for i, item := range items {
+		if item == nil {
+			item = make(map[string]interface{})
+			items[i] = item
+		}
+		mergeParams(c, item, o)
+	}
+	return items, nil
+}
+
+// validateSlice 逐个元素执行校验
+func validateSlice(c *gin.Context, items []map[string]interface{}, rules []validator.Filter, o *options) ([]map[string]interface{}, int32, error) {
+	res := make([]map[string]interface{}, len(items))
+	var errs ValidationErrors
+	var errCode int32
+	for i, item := range items {
+		if err := contextErr(c, o); err != nil {
+			return nil, 0, err
+		}
+		v, code, err := validate(c, item, rules, o)
+		if err == nil {
+			res[i] = v
+			continue
+		}
+		if cErr := contextErr(c, o); cErr != nil {
+			return nil, 0, cErr
+		}
+		itemErrs := indexErrors(i, err)
+		if o.batchPolicy == BatchRejectAll && !o.collectAll {
+			return nil, code, itemErrs[0]
+		}
+		if len(errs) == 0 {
+			errCode = code
+		}
+		errs = append(errs, itemErrs...)
+	}
+	if len(errs) == 0 {
+		return res, 0, nil
+	}
+	if o.batchPolicy == BatchPartial {
+		return res, errCode, errs
+	}
+	return nil, errCode, errs
+}
+
+// indexErrors 为校验失败的参数KEY增加元素下标
+func indexErrors(i int, err error) ValidationErrors {
+	var errs ValidationErrors
+	var vErr *ValidationError
+	if !errors.As(err, &errs) {
+		if !errors.As(err, &vErr) {
+			return ValidationErrors{{Field: fmt.Sprintf("[%d]", i), Message: err.Error(), Err: err}}
+		}
+		errs = ValidationErrors{vErr}
+	}
+	res := make(ValidationErrors, 0, len(errs))
+	for _, e := range errs {
+		ie := *e
+		ie.Field = fmt.Sprintf("[%d].%s", i, e.Field)
+		res = append(res, &ie)
+	}
+	return res
+}
@@ -0,0 +1,48 @@
+package ginvalidate
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newBatchContext() *gin.Context {
+	body := `[{"name":"a","ids":"1"},{"name":"b"},{"ids":"3"}]`
+	return newTestContext("POST", "/batch", "application/json", strings.NewReader(body))
+}
+
+func TestBindJsonSlice(t *testing.T) {
+
+	_, _, err := BindJsonSlice(newBatchContext(), multiRules)
+	var vErr *ValidationError
+	if !errors.As(err, &vErr) || vErr.Field != "[1].ids" {
+		t.Fatalf("batch reject error: %v", err)
+	}
+
+	res, _, err := BindJsonSlice(newBatchContext(), multiRules, WithBatchPolicy(BatchPartial))
+	var errs ValidationErrors
+	if !errors.As(err, &errs) || len(errs) != 2 || errs[0].Field != "[1].ids" || errs[1].Field != "[2].name" {
+		t.Fatalf("batch partial error: %v", err)
+	}
+	if len(res) != 3 || res[0]["name"] != "a" || res[1] != nil || res[2] != nil {
+		t.Errorf("batch partial result error: %v", res)
+	}
+
+	type item struct {
+		Name string `json:"name"`
+		Ids  string `json:"ids"`
+	}
+	var out []item
+	_, err = BindJsonStructSlice(newBatchContext(), multiRules, &out, WithBatchPolicy(BatchPartial))
+	if err == nil || len(out) != 3 || out[0].Name != "a" || out[1].Name != "" {
+		t.Errorf("batch struct slice error: %v %v", err, out)
+	}
+
+	c := newTestContext("POST", "/batch", "", strings.NewReader(`{"name":"a"}`))
+	var pErr *ParseError
+	if _, _, err = BindJsonSlice(c, multiRules); !errors.As(err, &pErr) {
+		t.Errorf("batch object body should fail: %v", err)
+	}
+}
@@ -0,0 +1,94 @@
+package ginvalidate
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ugorji/go/codec"
+)
+
+// MIMECBOR CBOR请求体的Content-Type
+const MIMECBOR = "application/cbor"
+
+// BinaryMode msgpack bin 与 CBOR byte string 类型的值在参数中的表示方式
+type BinaryMode int
+
+const (
+	// BinaryBytes 保持为 []byte，可转为 []byte 类型的字段
+	BinaryBytes BinaryMode = iota
+	// BinaryBase64 转为标准base64编码的字符串
+	BinaryBase64
+	// BinaryUTF8 转为字符串，内容不是合法的UTF-8时解析失败
+	BinaryUTF8
+)
+
+// collectMsgpack 解析MessagePack参数
+func collectMsgpack(c *gin.Context, o *options) (map[string]interface{}, error) {
+	return collectBody(c, o, func(r io.Reader) (map[string]interface{}, error) {
+		h := &codec.MsgpackHandle{WriteExt: true}
+		return decodeBinary(codec.NewDecoder(r, h), o.binaryMode)
+	})
+}
+
+// collectCBOR 解析CBOR参数
+func collectCBOR(c *gin.Context, o *options) (map[string]interface{}, error) {
+	return collectBody(c, o, func(r io.Reader) (map[string]interface{}, error) {
+		return decodeBinary(codec.NewDecoder(r, &codec.CborHandle{}), o.binaryMode)
+	})
+}
+
+// decodeBinary 解码二进制格式的请求体，空body返回空map
+// 文本类型的值为字符串，二进制类型的值按mode转换
+func decodeBinary(decoder *codec.Decoder, mode BinaryMode) (map[string]interface{}, error) {
+	var v interface{}
+	err := decoder.Decode(&v)
+	if errors.Is(err, io.EOF) {
+		return make(map[string]interface{}), nil
+	}
+	if err != nil {
+		return make(map[string]interface{}), err
+	}
+	v, err = binaryValue(normalizeValue(v), mode)
+	if err != nil {
+		return make(map[string]interface{}), err
+	}
+	return toParamsMap(v)
+}
+
+// binaryValue 按mode转换 []byte 类型的值
+func binaryValue(v interface{}, mode BinaryMode) (interface{}, error) {
+	if mode == BinaryBytes {
+		return v, nil
+	}
+	switch tv := v.(type) {
+	case map[string]interface{}:
+		for k, ev := range tv {
+			nv, err := binaryValue(ev, mode)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", k, err)
+			}
+			tv[k] = nv
+		}
+	case []interface{}:
+		for i, ev := range tv {
+			nv, err := binaryValue(ev, mode)
+			if err != nil {
+				return nil, fmt.Errorf("[%d]: %w", i, err)
+			}
+			tv[i] = nv
+		}
+	case []byte:
+		if mode == BinaryBase64 {
+			return base64.StdEncoding.EncodeToString(tv), nil
+		}
+		if !utf8.Valid(tv) {
+			return nil, errors.New("binary value is not valid UTF-8")
+		}
+		return string(tv), nil
+	}
+	return v, nil
+}
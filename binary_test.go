@@ -0,0 +1,60 @@
+package ginvalidate
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/ugorji/go/codec"
+)
+
+func TestBindMsgpackCbor(t *testing.T) {
+
+	s1 := map[string]interface{}{
+		"name":  "课件",
+		"ids":   "1,2,3",
+		"grade": 2,
+		"sign":  []byte{0xff, 0x00},
+	}
+
+	cases := []struct {
+		ct string
+		h  codec.Handle
+	}{
+		{"application/msgpack", &codec.MsgpackHandle{WriteExt: true}},
+		{"application/x-msgpack", &codec.MsgpackHandle{WriteExt: true}},
+		{"application/cbor", &codec.CborHandle{}},
+	}
+
+	for _, cs := range cases {
+		buf := new(bytes.Buffer)
+		if err := codec.NewEncoder(buf, cs.h).Encode(s1); err != nil {
+			t.Fatal(err)
+		}
+		body := buf.Bytes()
+
+		c := newTestContext("POST", "/binary", cs.ct, bytes.NewReader(body))
+
+		res, _, err := Bind(c, multiRules)
+		if err != nil {
+			t.Fatalf("%s: %v", cs.ct, err)
+		}
+		if res["name"] != "课件" || res["grade"] != json.Number("2") {
+			t.Errorf("%s: bind error: %v", cs.ct, res)
+		}
+		if b, ok := res["sign"].([]byte); !ok || !bytes.Equal(b, []byte{0xff, 0x00}) {
+			t.Errorf("%s: binary value error: %#v", cs.ct, res["sign"])
+		}
+
+		c = newTestContext("POST", "/binary", cs.ct, bytes.NewReader(body))
+		res, _, err = Bind(c, multiRules, WithBinaryMode(BinaryBase64))
+		if err != nil || res["sign"] != "/wA=" {
+			t.Errorf("%s: base64 binary value error: %v %#v", cs.ct, err, res["sign"])
+		}
+
+		c = newTestContext("POST", "/binary", cs.ct, bytes.NewReader(body))
+		if _, _, err = Bind(c, multiRules, WithBinaryMode(BinaryUTF8)); err == nil {
+			t.Errorf("%s: invalid UTF-8 binary value should fail", cs.ct)
+		}
+	}
+}
@@ -0,0 +1,423 @@
+package ginvalidate
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/rumis/govalidate"
+	"github.com/rumis/govalidate/validator"
+)
+
+// Source 参数来源
+type Source int
+
+const (
+	// SourceAuto 根据请求方法与Content-Type自动选择
+	SourceAuto Source = iota
+	// SourceJSON Content-type:application/json
+	SourceJSON
+	// SourceForm Content-type:application/x-www-form-urlencoded 或 multipart/form-data
+	SourceForm
+	// SourceQuery URL查询参数
+	SourceQuery
+	// SourceHeader 仅解析路径参数与header参数，header的范围同样由 WithHeaders 等选项决定
+	SourceHeader
+	// SourceXML Content-type:application/xml 或 text/xml
+	SourceXML
+	// SourceYAML Content-type:application/yaml 或 application/x-yaml
+	SourceYAML
+	// SourceTOML Content-type:application/toml
+	SourceTOML
+	// SourceMsgpack Content-type:application/msgpack 或 application/x-msgpack
+	SourceMsgpack
+	// SourceCBOR Content-type:application/cbor
+	SourceCBOR
+	// SourceProtobuf Content-type:application/x-protobuf，仅由 BindProto 解析
+	SourceProtobuf
+)
+
+// collector 从请求中收集待校验的参数
+type collector func(c *gin.Context, o *options) (map[string]interface{}, error)
+
+// collectors 各参数来源的解析方式
+var collectors = map[Source]collector{
+	SourceJSON:    collectJSON,
+	SourceForm:    collectForm,
+	SourceQuery:   collectQuery,
+	SourceHeader:  collectHeader,
+	SourceXML:     collectXML,
+	SourceYAML:    collectYAML,
+	SourceTOML:    collectTOML,
+	SourceMsgpack: collectMsgpack,
+	SourceCBOR:    collectCBOR,
+}
+
+// Bind 根据请求方法与Content-Type解析并校验参数
+func Bind(c *gin.Context, rules []validator.Filter, opts ...Option) (map[string]interface{}, int32, error) {
+	o := newOptions(opts)
+	src, err := negotiate(c, o)
+	if err != nil {
+		return nil, 0, err
+	}
+	collect, ok := collectors[src]
+	if !ok {
+		return nil, 0, &UnsupportedMediaTypeError{ContentType: c.ContentType()}
+	}
+	body := limitBody(c, o)
+	replay := bufferBody(c, o)
+	params, err := collect(c, o)
+	replay.restore(c)
+	if body != nil && body.err != nil {
+		return params, 0, body.err
+	}
+	if err != nil {
+		return params, 0, &ParseError{Source: src, Err: err}
+	}
+	// 校验
+	res, errCode, err := validate(c, params, rules, o)
+	if err != nil {
+		return params, errCode, err
+	}
+	return res, errCode, nil
+}
+
+// BindStruct 根据请求方法与Content-Type解析并校验参数，返回值为对象
+func BindStruct(c *gin.Context, rules []validator.Filter, obj interface{}, opts ...Option) (int32, error) {
+	errCode, _, err := bindStruct(c, rules, obj, opts)
+	return errCode, err
+}
+
+// bindStruct 解析参数并转为对象
+// 若失败，返回map格式的原始数据
+func bindStruct(c *gin.Context, rules []validator.Filter, obj interface{}, opts []Option) (int32, interface{}, error) {
+	res, errCode, err := Bind(c, rules, opts...)
+	if err != nil {
+		return errCode, res, err
+	}
+	err = mapDecode(res, obj, newOptions(opts).decoder)
+	if err != nil {
+		return 0, res, &DecodeError{Err: err}
+	}
+	return errCode, nil, nil
+}
+
+// negotiate 选择参数来源
+func negotiate(c *gin.Context, o *options) (Source, error) {
+	if o.source != SourceAuto {
+		return o.source, nil
+	}
+	switch c.Request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodDelete, http.MethodOptions:
+		return SourceQuery, nil
+	}
+	ct := c.ContentType()
+	switch {
+	case ct == "" && c.Request.ContentLength <= 0:
+		return SourceQuery, nil
+	case ct == binding.MIMEJSON || strings.HasSuffix(ct, "+json"):
+		return SourceJSON, nil
+	case ct == binding.MIMEPOSTForm || ct == binding.MIMEMultipartPOSTForm:
+		return SourceForm, nil
+	case ct == binding.MIMEXML || ct == binding.MIMEXML2 || strings.HasSuffix(ct, "+xml"):
+		return SourceXML, nil
+	case ct == MIMEYAML || ct == binding.MIMEYAML || ct == "text/yaml":
+		return SourceYAML, nil
+	case ct == MIMETOML:
+		return SourceTOML, nil
+	case ct == binding.MIMEMSGPACK || ct == binding.MIMEMSGPACK2:
+		return SourceMsgpack, nil
+	case ct == MIMECBOR:
+		return SourceCBOR, nil
+	}
+	return SourceAuto, &UnsupportedMediaTypeError{ContentType: ct}
+}
+
+// validate 执行校验，失败时返回 *ValidationError，WithCollectAll 时返回 ValidationErrors
+func validate(c *gin.Context, params map[string]interface{}, rules []validator.Filter, o *options) (map[string]interface{}, int32, error) {
+	rules, names := namedRules(rules, o)
+	rules, err := expandRules(params, rules, o)
+	if err != nil {
+		return nil, 0, err
+	}
+	errs := validateUnknown(c, params, rules, o)
+	if len(errs) == 0 || o.collectAll {
+		res, vErrs, err := runValidate(c, params, rules, names, o)
+		if err != nil {
+			return nil, 0, err
+		}
+		if len(errs) == 0 && len(vErrs) == 0 {
+			return res, 0, nil
+		}
+		errs = append(errs, vErrs...)
+	}
+	if o.collectAll {
+		return nil, errs[0].Code, errs
+	}
+	return nil, errs[0].Code, errs[0]
+}
+
+// runValidate 按规则顺序逐个执行校验，每个规则校验前一个规则的结果
+// 校验失败时停止，WithCollectAll 时继续执行后续规则，收集全部校验失败的参数
+// WithContext 时请求取消或超时返回context的错误
+func runValidate(c *gin.Context, params map[string]interface{}, rules []validator.Filter, names map[*validator.Validator]Filter, o *options) (map[string]interface{}, ValidationErrors, error) {
+	res := make(map[string]interface{}, len(params))
+	for k, v := range params {
+		res[k] = v
+	}
+	var errs ValidationErrors
+	copied := make(map[string]bool)
+	for _, f := range rules {
+		if err := contextErr(c, o); err != nil {
+			return nil, nil, err
+		}
+		failed := -1
+		errCode, err := applyFilter(c, res, trackValidators(f, &failed), copied, o)
+		if err == nil {
+			continue
+		}
+		// 校验器因请求取消而失败时不作为校验失败
+		if cErr := contextErr(c, o); cErr != nil {
+			return nil, nil, cErr
+		}
+		errs = append(errs, newValidationError(res, f, failed, names, errCode, err, o))
+		if !o.collectAll {
+			break
+		}
+	}
+	return res, errs, nil
+}
+
+// namedRules 在规则之后追加 WithFilters 的规则，并返回校验器名称
+// 名称以规则的校验器切片首元素地址为KEY，通配符展开的规则与原规则共用校验器切片
+func namedRules(rules []validator.Filter, o *options) ([]validator.Filter, map[*validator.Validator]Filter) {
+	if len(o.filters) == 0 {
+		return rules, nil
+	}
+	res := append(make([]validator.Filter, 0, len(rules)+len(o.filters)), rules...)
+	names := make(map[*validator.Validator]Filter, len(o.filters))
+	for _, nf := range o.filters {
+		f := nf.To()
+		if len(f.Validators) > 0 {
+			names[&f.Validators[0]] = nf
+		}
+		res = append(res, f)
+	}
+	return res, names
+}
+
+// contextErr WithContext 时返回请求context的错误
+func contextErr(c *gin.Context, o *options) error {
+	if !o.context {
+		return nil
+	}
+	return c.Request.Context().Err()
+}
+
+// applyFilter 执行单个校验规则，并将校验后的值写回res
+// copied 记录已拷贝的嵌套参数，每个嵌套参数只拷贝一次
+func applyFilter(c *gin.Context, res map[string]interface{}, f validator.Filter, copied map[string]bool, o *options) (int32, error) {
+	out, errCode, err := runFilter(c, res, f, o)
+	if err != nil {
+		return errCode, err
+	}
+	if !isPathKey(res, f.Key, o) {
+		if _, ok := out[f.Key]; !ok {
+			delete(res, f.Key)
+		}
+		for k, v := range out {
+			res[k] = v
+		}
+		return errCode, nil
+	}
+	// 写回前拷贝嵌套参数，不修改原始参数
+	root := strings.SplitN(f.Key, PathSeparator, 2)[0]
+	if !copied[root] {
+		if v, ok := res[root]; ok {
+			res[root] = copyValue(v)
+		}
+		copied[root] = true
+	}
+	writePath(res, f.Key, out)
+	return errCode, nil
+}
+
+// runFilter 执行单个校验规则，不修改res
+// 嵌套参数路径的规则仅以路径对应的值执行校验
+func runFilter(c *gin.Context, res map[string]interface{}, f validator.Filter, o *options) (map[string]interface{}, int32, error) {
+	params := res
+	if isPathKey(res, f.Key, o) {
+		params = make(map[string]interface{}, 1)
+		if v, ok := getPath(res, strings.Split(f.Key, PathSeparator)); ok {
+			params[f.Key] = v
+		}
+	}
+	return runGovalidate(c, params, []validator.Filter{f}, o)
+}
+
+// runGovalidate 调用govalidate执行校验
+func runGovalidate(c *gin.Context, params map[string]interface{}, rules []validator.Filter, o *options) (map[string]interface{}, int32, error) {
+	if o.context {
+		return govalidate.Validate1(toContext(c), params, rules)
+	}
+	return govalidate.Validate(params, rules)
+}
+
+// trackValidators 包装规则中的校验器，failed记录第一个返回错误的校验器下标
+// 校验器按原顺序只执行一次，不重复执行已通过的校验器
+func trackValidators(f validator.Filter, failed *int) validator.Filter {
+	validators := make([]validator.Validator, len(f.Validators))
+	for i, v := range f.Validators {
+		i, fv := i, reflect.ValueOf(v)
+		if !fv.IsValid() || fv.Kind() != reflect.Func {
+			validators[i] = v
+			continue
+		}
+		validators[i] = reflect.MakeFunc(fv.Type(), func(args []reflect.Value) []reflect.Value {
+			out := callValidator(fv, args)
+			if *failed < 0 && len(out) > 0 {
+				if err, ok := out[len(out)-1].Interface().(error); ok && err != nil {
+					*failed = i
+				}
+			}
+			return out
+		}).Interface().(validator.Validator)
+	}
+	return validator.Filter{Key: f.Key, Validators: validators}
+}
+
+// newValidationError 创建校验失败的错误，index为校验失败的校验器下标，无法确定时为-1
+func newValidationError(res map[string]interface{}, f validator.Filter, index int, names map[*validator.Validator]Filter, errCode int32, err error, o *options) *ValidationError {
+	vErr := &ValidationError{
+		Field:   f.Key,
+		Index:   index,
+		Value:   lookupValue(res, f.Key, o),
+		Code:    errCode,
+		Message: err.Error(),
+		Err:     err,
+	}
+	if index < 0 {
+		return vErr
+	}
+	if nf, ok := names[&f.Validators[0]]; ok && index < len(nf.Rules) {
+		vErr.Rule = nf.Rules[index].Name
+	}
+	return vErr
+}
+
+// collectJSON 解析JSON参数
+func collectJSON(c *gin.Context, o *options) (map[string]interface{}, error) {
+	return collectBody(c, o, decodeJSONBody)
+}
+
+// decodeJSONBody 以 json.Number 解析JSON请求体，请求体为空时返回空map
+func decodeJSONBody(r io.Reader) (map[string]interface{}, error) {
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+	res := make(map[string]interface{})
+	err := decoder.Decode(&res)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return res, err
+	}
+	return res, nil
+}
+
+// collectQuery 解析Query参数
+func collectQuery(c *gin.Context, o *options) (map[string]interface{}, error) {
+	pCol := NewParamsCollection()
+	// 解析查询参数
+	values := c.Request.URL.Query()
+	slots := nestedSlotLimit
+	for k, v := range values {
+		setParam(pCol, k, v, &slots, o)
+	}
+	// 解析路径参数
+	bindPathParams(c, pCol.To(), o)
+	// 解析header参数
+	bindHeaders(c, o, pCol.Set)
+	return pCol.To(), nil
+}
+
+// collectForm 解析form数据
+func collectForm(c *gin.Context, o *options) (map[string]interface{}, error) {
+	if err := c.Request.ParseForm(); err != nil {
+		return nil, err
+	}
+	pCol := NewParamsCollection()
+	values := c.Request.PostForm
+	slots := nestedSlotLimit
+	for k, v := range values {
+		setParam(pCol, k, v, &slots, o)
+	}
+	// 解析MultipartForm
+	err := c.Request.ParseMultipartForm(o.multipartMemory)
+	if err == nil {
+		for k, v := range c.Request.MultipartForm.Value {
+			setParam(pCol, k, v, &slots, o)
+		}
+		// 上传的文件
+		for k, v := range c.Request.MultipartForm.File {
+			k = FormatKey(k)
+			pCol.SetFiles(k, v)
+		}
+	}
+	// 解析路径参数
+	bindPathParams(c, pCol.To(), o)
+	// 解析Header参数
+	bindHeaders(c, o, pCol.Set)
+	return pCol.To(), nil
+}
+
+// collectHeader 解析header参数
+func collectHeader(c *gin.Context, o *options) (map[string]interface{}, error) {
+	pCol := NewParamsCollection()
+	// 解析路径参数
+	bindPathParams(c, pCol.To(), o)
+	// 解析Header参数
+	bindHeaders(c, o, pCol.Set)
+	return pCol.To(), nil
+}
+
+// setParam 设置查询参数或form参数，WithNestedKeys 时按中括号解析层级
+// slots为请求中剩余可分配的切片元素数量
+func setParam(pCol ParamsCollection, k string, v []string, slots *int, o *options) {
+	if o.nestedKeys {
+		pCol.setNestedSlots(k, v, slots)
+		return
+	}
+	pCol.Set(FormatKey(k), v)
+}
+
+// mergeParams 合并路径参数与header参数，同名header的多个值以逗号连接
+func mergeParams(c *gin.Context, params map[string]interface{}, o *options) {
+	// 解析路径参数
+	bindPathParams(c, params, o)
+	// 解析header参数
+	bindHeaders(c, o, func(k string, v []string) {
+		params[k] = strings.Join(v, ",")
+	})
+}
+
+// bindHeaders 合并header参数，仅合并 WithHeaders 允许的header
+func bindHeaders(c *gin.Context, o *options, set func(k string, v []string)) {
+	for k, v := range c.Request.Header {
+		if key, ok := o.headerKey(k); ok {
+			set(key, v)
+		}
+	}
+}
+
+// bindPathParams 合并路径参数
+func bindPathParams(c *gin.Context, params map[string]interface{}, o *options) {
+	for _, p := range c.Params {
+		if _, ok := params[p.Key]; ok && o.pathPrecedence == PathParamsLast {
+			continue
+		}
+		params[p.Key] = p.Value
+	}
+}
@@ -0,0 +1,92 @@
+package ginvalidate
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hetiansu5/urlquery"
+	"github.com/mitchellh/mapstructure"
+)
+
+func TestBindNegotiate(t *testing.T) {
+
+	s1 := map[string]interface{}{
+		"name":     "课件",
+		"ids":      "1,2,3",
+		"grade":    2,
+		"subjects": []int{3, 4, 12},
+		"ctime":    time.Now().Format("2006-01-02 15:04:05"),
+		"email":    "liumurong1@tal.com",
+		"phone":    "15810562936",
+		"stat":     3,
+		"school":   1,
+		"cname":    []string{"a", "b", "c"},
+	}
+	s1Byte, _ := json.Marshal(s1)
+	bs, _ := urlquery.Marshal(s1)
+
+	cases := []struct {
+		contentType string
+		body        []byte
+		code        int
+	}{
+		{"application/json", s1Byte, http.StatusOK},
+		{"application/x-www-form-urlencoded", bs, http.StatusOK},
+		{"text/plain", []byte("name=课件"), http.StatusUnsupportedMediaType},
+	}
+
+	for _, cs := range cases {
+		req := httptest.NewRequest("POST", "/bind", bytes.NewReader(cs.body))
+		req.Header.Add("Content-Type", cs.contentType)
+
+		w := httptest.NewRecorder()
+		// 调用相应的handler接口
+		router.ServeHTTP(w, req)
+
+		if w.Code != cs.code {
+			t.Errorf("%s code error: %d", cs.contentType, w.Code)
+			continue
+		}
+		if w.Code != http.StatusOK {
+			continue
+		}
+
+		var resp Resp
+		json.Unmarshal(w.Body.Bytes(), &resp)
+		var out SlideResp
+		mapstructure.Decode(resp.Data, &out)
+		if out.Name != "课件" {
+			t.Errorf("%s bind error", cs.contentType)
+		}
+	}
+}
+
+// bindHandler 根据Content-Type自动解析
+func bindHandler(c *gin.Context) {
+	var s SlideResp
+	code, err := BindStruct(c, rules, &s)
+	if err != nil {
+		status := http.StatusOK
+		var mediaErr *UnsupportedMediaTypeError
+		if errors.As(err, &mediaErr) {
+			status = mediaErr.StatusCode()
+		}
+		c.JSON(status, Resp{
+			Code: int(code),
+			Msg:  err.Error(),
+			Data: gin.H{},
+		})
+		return
+	}
+	c.JSON(http.StatusOK, Resp{
+		Code: int(code),
+		Msg:  "",
+		Data: s,
+	})
+}
@@ -0,0 +1,43 @@
+package ginvalidate
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// 测试校验器context继承请求的context
+func TestToContext(t *testing.T) {
+
+	reqCtx, cancel := context.WithCancel(context.Background())
+	c := newTestContext("GET", "/query", "", nil)
+	c.Request = c.Request.WithContext(reqCtx)
+	c.Set("uid", 1)
+
+	ctx := toContext(c)
+	if uid, _ := ctx.Value("uid").(int); uid != 1 {
+		t.Error("gin context keys not exposed")
+	}
+	if gc, ok := GinContext(ctx); !ok || gc != c {
+		t.Error("gin context not exposed")
+	}
+
+	cancel()
+	select {
+	case <-ctx.Done():
+	default:
+		t.Error("request cancellation not propagated")
+	}
+
+	// 请求取消时返回context的错误，而不是校验失败
+	for _, opt := range []Option{WithContext(), WithCollectAll()} {
+		c = newTestContext("POST", "/json", "application/json", strings.NewReader(`{"grade":1000}`))
+		c.Request = c.Request.WithContext(reqCtx)
+		_, _, err := BindJsonMap(c, rules, WithContext(), opt)
+		var vErr *ValidationError
+		if !errors.Is(err, context.Canceled) || errors.As(err, &vErr) {
+			t.Errorf("canceled validate error: %v", err)
+		}
+	}
+}
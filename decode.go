@@ -0,0 +1,224 @@
+package ginvalidate
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+// EpochUnit 数字转为time.Time时的单位
+type EpochUnit int
+
+const (
+	// EpochNone 不支持数字转为time.Time
+	EpochNone EpochUnit = iota
+	// EpochSeconds 按Unix秒解析
+	EpochSeconds
+	// EpochMillis 按Unix毫秒解析
+	EpochMillis
+)
+
+// DecoderOptions 校验结果转为对象时的转换配置
+type DecoderOptions struct {
+	// TimeLayouts 字符串转为time.Time时依次尝试的格式，为空时使用默认格式
+	TimeLayouts []string
+	// Location 不含时区的时间字符串使用的时区，为空时为UTC
+	Location *time.Location
+	// Epoch 数字或数字字符串转为time.Time时的单位
+	Epoch EpochUnit
+	// Hooks 单次调用的类型转换，先于 RegisterDecodeHook 注册的转换执行
+	Hooks []DecodeHook
+	// ErrorUnused 参数中存在对象没有的字段时转换失败
+	ErrorUnused bool
+	// ErrorUnset 对象中存在参数没有的字段时转换失败
+	ErrorUnset bool
+}
+
+// DecodeHook 校验结果转为对象时的类型转换，from为参数值的类型，to为字段的类型
+// 不处理时原样返回data
+type DecodeHook func(from reflect.Type, to reflect.Type, data interface{}) (interface{}, error)
+
+// decodeHooks 通过 RegisterDecodeHook 注册的类型转换
+var decodeHooks []DecodeHook
+
+// RegisterDecodeHook 注册全局的类型转换，先于内置的转换执行
+// 非并发安全，应在程序初始化阶段调用
+func RegisterDecodeHook(hooks ...DecodeHook) {
+	decodeHooks = append(decodeHooks, hooks...)
+}
+
+// defaultTimeLayouts 默认支持的时间格式
+var defaultTimeLayouts = []string{"2006-01-02 15:04:05", "2006-01-02"}
+
+var (
+	timeType            = reflect.TypeOf(time.Time{})
+	durationType        = reflect.TypeOf(time.Duration(0))
+	urlType             = reflect.TypeOf(url.URL{})
+	bigIntType          = reflect.TypeOf(big.Int{})
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+)
+
+// hooks mapDecode依次执行的类型转换
+func (d DecoderOptions) hooks() []mapstructure.DecodeHookFunc {
+	res := make([]mapstructure.DecodeHookFunc, 0, len(d.Hooks)+len(decodeHooks)+7)
+	for _, h := range d.Hooks {
+		res = append(res, h)
+	}
+	for _, h := range decodeHooks {
+		res = append(res, h)
+	}
+	return append(res,
+		d.timeHookFunc(),
+		fileHeaderHookFunc(),
+		durationHookFunc,
+		urlHookFunc,
+		bigIntHookFunc,
+		textUnmarshalerHookFunc,
+		jsonUnmarshalerHookFunc)
+}
+
+// timeHookFunc 按配置将字符串与数字转为time.Time
+func (d DecoderOptions) timeHookFunc() func(reflect.Type, reflect.Type, interface{}) (interface{}, error) {
+	loc := d.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	layouts := d.TimeLayouts
+	if len(layouts) == 0 {
+		layouts = defaultTimeLayouts
+	}
+	return func(from reflect.Type, to reflect.Type, data interface{}) (interface{}, error) {
+		if to != timeType {
+			return data, nil
+		}
+		switch v := data.(type) {
+		case string:
+			s := strings.TrimSpace(v)
+			if s == "" {
+				return time.Time{}, nil
+			}
+			for _, layout := range layouts {
+				if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+					return t, nil
+				}
+			}
+			if d.Epoch != EpochNone {
+				if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+					return d.epochTime(n, loc), nil
+				}
+			}
+			return nil, fmt.Errorf("parsing time %q: no matching layout in %v", s, layouts)
+		case json.Number:
+			if d.Epoch == EpochNone {
+				return data, nil
+			}
+			n, err := v.Int64()
+			if err != nil {
+				return nil, fmt.Errorf("parsing time %q: %w", v, err)
+			}
+			return d.epochTime(n, loc), nil
+		}
+		if d.Epoch == EpochNone {
+			return data, nil
+		}
+		rv := reflect.ValueOf(data)
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return d.epochTime(rv.Int(), loc), nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return d.epochTime(int64(rv.Uint()), loc), nil
+		case reflect.Float32, reflect.Float64:
+			return d.epochTime(int64(rv.Float()), loc), nil
+		}
+		return data, nil
+	}
+}
+
+// epochTime 按单位将Unix时间戳转为time.Time
+func (d DecoderOptions) epochTime(n int64, loc *time.Location) time.Time {
+	if d.Epoch == EpochMillis {
+		return time.Unix(n/1000, n%1000*int64(time.Millisecond)).In(loc)
+	}
+	return time.Unix(n, 0).In(loc)
+}
+
+// durationHookFunc 将 "1h30m" 形式的字符串或纳秒数转为time.Duration
+func durationHookFunc(from reflect.Type, to reflect.Type, data interface{}) (interface{}, error) {
+	if to != durationType {
+		return data, nil
+	}
+	switch v := data.(type) {
+	case string:
+		return time.ParseDuration(strings.TrimSpace(v))
+	case json.Number:
+		n, err := v.Int64()
+		return time.Duration(n), err
+	}
+	return data, nil
+}
+
+// urlHookFunc 将字符串转为url.URL
+func urlHookFunc(from reflect.Type, to reflect.Type, data interface{}) (interface{}, error) {
+	s, ok := data.(string)
+	if to != urlType || !ok {
+		return data, nil
+	}
+	u, err := url.Parse(s)
+	if err != nil {
+		return nil, err
+	}
+	return *u, nil
+}
+
+// bigIntHookFunc 将整数转为big.Int，字符串与json.Number由 textUnmarshalerHookFunc 处理
+func bigIntHookFunc(from reflect.Type, to reflect.Type, data interface{}) (interface{}, error) {
+	if to != bigIntType || data == nil {
+		return data, nil
+	}
+	rv := reflect.ValueOf(data)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return *new(big.Int).SetInt64(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return *new(big.Int).SetUint64(rv.Uint()), nil
+	}
+	return data, nil
+}
+
+// textUnmarshalerHookFunc 字段实现 encoding.TextUnmarshaler 时，以字符串参数调用UnmarshalText
+// 如 uuid.UUID、net.IP、big.Int
+func textUnmarshalerHookFunc(from reflect.Type, to reflect.Type, data interface{}) (interface{}, error) {
+	if data == nil || from == to || from.Kind() != reflect.String || !reflect.PtrTo(to).Implements(textUnmarshalerType) {
+		return data, nil
+	}
+	v := reflect.New(to)
+	if err := v.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(reflect.ValueOf(data).String())); err != nil {
+		return nil, err
+	}
+	return v.Elem().Interface(), nil
+}
+
+// jsonUnmarshalerHookFunc 字段实现 json.Unmarshaler 时，将参数转为JSON后调用UnmarshalJSON
+func jsonUnmarshalerHookFunc(from reflect.Type, to reflect.Type, data interface{}) (interface{}, error) {
+	if data == nil || from == to || !reflect.PtrTo(to).Implements(jsonUnmarshalerType) {
+		return data, nil
+	}
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	v := reflect.New(to)
+	if err := v.Interface().(json.Unmarshaler).UnmarshalJSON(b); err != nil {
+		return nil, err
+	}
+	return v.Elem().Interface(), nil
+}
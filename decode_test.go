@@ -0,0 +1,135 @@
+package ginvalidate
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDecodeTime(t *testing.T) {
+
+	type timeReq struct {
+		Ctime time.Time  `json:"ctime"`
+		Mtime *time.Time `json:"mtime"`
+	}
+
+	shanghai := time.FixedZone("CST", 8*3600)
+	d := DecoderOptions{TimeLayouts: append([]string{time.RFC3339}, defaultTimeLayouts...), Location: shanghai}
+
+	var out timeReq
+	err := mapDecode(map[string]interface{}{
+		"ctime": "2021-10-01 08:00:00",
+		"mtime": "2021-10-01T08:00:00Z",
+	}, &out, d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !out.Ctime.Equal(time.Date(2021, 10, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("local time decode error: %v", out.Ctime)
+	}
+	if out.Mtime == nil || !out.Mtime.Equal(time.Date(2021, 10, 1, 8, 0, 0, 0, time.UTC)) {
+		t.Errorf("rfc3339 decode error: %v", out.Mtime)
+	}
+
+	d.Epoch = EpochSeconds
+	if err = mapDecode(map[string]interface{}{"ctime": json.Number("1633046400"), "mtime": "1633046400"}, &out, d); err != nil {
+		t.Fatal(err)
+	}
+	if out.Ctime.Unix() != 1633046400 || out.Mtime.Unix() != 1633046400 {
+		t.Errorf("epoch seconds decode error: %v %v", out.Ctime, out.Mtime)
+	}
+
+	d.Epoch = EpochMillis
+	if err = mapDecode(map[string]interface{}{"ctime": 1633046400123}, &out, d); err != nil {
+		t.Fatal(err)
+	}
+	if out.Ctime.UnixNano() != 1633046400123*int64(time.Millisecond) {
+		t.Errorf("epoch millis decode error: %v", out.Ctime)
+	}
+
+	if err = mapDecode(map[string]interface{}{"ctime": "2021/10/01"}, &out, DecoderOptions{TimeLayouts: defaultTimeLayouts}); err == nil {
+		t.Error("unknown layout should fail")
+	}
+
+	// 未设置 TimeLayouts 时使用默认格式
+	o := newOptions([]Option{WithDecoderOptions(DecoderOptions{Location: shanghai})})
+	if err = mapDecode(map[string]interface{}{"ctime": "2021-10-01 08:00:00"}, &out, o.decoder); err != nil {
+		t.Fatal(err)
+	}
+	if !out.Ctime.Equal(time.Date(2021, 10, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("default layouts decode error: %v", out.Ctime)
+	}
+}
+
+type level int
+
+type jsonPoint struct {
+	X, Y int
+}
+
+func (p *jsonPoint) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	_, err := fmt.Sscanf(s, "%d,%d", &p.X, &p.Y)
+	return err
+}
+
+func TestDecodeHooks(t *testing.T) {
+
+	type hookReq struct {
+		Timeout time.Duration `json:"timeout"`
+		IP      net.IP        `json:"ip"`
+		Site    url.URL       `json:"site"`
+		Amount  *big.Int      `json:"amount"`
+		Total   big.Int       `json:"total"`
+		Point   jsonPoint     `json:"point"`
+		Level   level         `json:"level"`
+	}
+
+	levels := map[string]level{"low": 1, "high": 2}
+	d := DecoderOptions{Hooks: []DecodeHook{func(from reflect.Type, to reflect.Type, data interface{}) (interface{}, error) {
+		s, ok := data.(string)
+		if to != reflect.TypeOf(level(0)) || !ok {
+			return data, nil
+		}
+		if l, ok := levels[strings.ToLower(s)]; ok {
+			return l, nil
+		}
+		return nil, fmt.Errorf("unknown level %q", s)
+	}}}
+
+	var out hookReq
+	err := mapDecode(map[string]interface{}{
+		"timeout": "1m30s",
+		"ip":      "10.0.0.1",
+		"site":    "https://example.com/a?b=1",
+		"amount":  json.Number("123456789012345678901234567890"),
+		"total":   42,
+		"point":   "3,4",
+		"level":   "High",
+	}, &out, d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.Timeout != 90*time.Second || !out.IP.Equal(net.ParseIP("10.0.0.1")) || out.Site.Host != "example.com" {
+		t.Errorf("builtin hooks error: %+v", out)
+	}
+	if out.Amount == nil || out.Amount.String() != "123456789012345678901234567890" || out.Total.Int64() != 42 {
+		t.Errorf("big int hooks error: %v %v", out.Amount, &out.Total)
+	}
+	if out.Point.X != 3 || out.Point.Y != 4 || out.Level != 2 {
+		t.Errorf("unmarshaler hooks error: %+v", out)
+	}
+
+	if err = mapDecode(map[string]interface{}{"level": "none"}, &out, d); err == nil {
+		t.Error("hook error should fail decode")
+	}
+}
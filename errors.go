@@ -0,0 +1,103 @@
+package ginvalidate
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// UnsupportedMediaTypeError 不支持的Content-Type
+type UnsupportedMediaTypeError struct {
+	ContentType string
+}
+
+func (e *UnsupportedMediaTypeError) Error() string {
+	return fmt.Sprintf("unsupported media type: %q", e.ContentType)
+}
+
+// StatusCode 对应的HTTP状态码
+func (e *UnsupportedMediaTypeError) StatusCode() int {
+	return http.StatusUnsupportedMediaType
+}
+
+// ValidationError 参数校验失败
+type ValidationError struct {
+	Field   string      // 校验失败的参数KEY
+	Rule    string      // 校验失败的校验器，如 Required、Between
+	Index   int         // 校验失败的校验器在 Filter.Validators 中的下标，无法确定时为-1
+	Value   interface{} // 被拒绝的参数值
+	Code    int32       // 错误码
+	Message string      // 错误信息
+	Err     error       // 校验器返回的原始错误
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationErrors 收集全部校验失败时返回的错误列表，按规则顺序排列
+type ValidationErrors []*ValidationError
+
+func (es ValidationErrors) Error() string {
+	msgs := make([]string, 0, len(es))
+	for _, e := range es {
+		msgs = append(msgs, e.Field+": "+e.Message)
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// As 支持通过 errors.As 获取第一个 *ValidationError
+func (es ValidationErrors) As(target interface{}) bool {
+	t, ok := target.(**ValidationError)
+	if !ok || len(es) == 0 {
+		return false
+	}
+	*t = es[0]
+	return true
+}
+
+// ParseError 请求参数解析失败，如JSON格式错误
+type ParseError struct {
+	Source Source
+	Err    error
+}
+
+func (e *ParseError) Error() string {
+	return "parse request params error: " + e.Err.Error()
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// DecodeError 校验结果转为对象失败
+type DecodeError struct {
+	Err error
+}
+
+func (e *DecodeError) Error() string {
+	return "decode params error: " + e.Err.Error()
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// PayloadTooLargeError 请求体超出限制
+type PayloadTooLargeError struct {
+	Limit int64  // 限制值
+	Kind  string // 超出的限制，body 为请求体字节数，parts 为multipart分段数量，paths 为通配符展开的路径数量
+}
+
+func (e *PayloadTooLargeError) Error() string {
+	return fmt.Sprintf("payload too large: %s exceeds %d", e.Kind, e.Limit)
+}
+
+// StatusCode 对应的HTTP状态码
+func (e *PayloadTooLargeError) StatusCode() int {
+	return http.StatusRequestEntityTooLarge
+}
@@ -0,0 +1,122 @@
+package ginvalidate
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	R "github.com/rumis/govalidate"
+	V "github.com/rumis/govalidate/validator"
+)
+
+// 测试结构化的校验错误
+func TestValidationError(t *testing.T) {
+
+	s1 := map[string]interface{}{
+		"ids":      "1,2,3",
+		"grade":    2,
+		"subjects": []int{3, 4, 12},
+		"ctime":    time.Now().Format("2006-01-02 15:04:05"),
+		"email":    "liumurong1@tal.com",
+		"phone":    "15810562936",
+		"stat":     3,
+		"school":   1,
+		"cname":    []string{"a", "b", "c"},
+	}
+	s1Byte, _ := json.Marshal(s1)
+
+	c := newTestContext("POST", "/json", "application/json", bytes.NewReader(s1Byte))
+
+	_, _, err := BindJsonMap(c, rules)
+	var vErr *ValidationError
+	if !errors.As(err, &vErr) {
+		t.Fatalf("validation error type error: %T", err)
+	}
+	if vErr.Field != "name" {
+		t.Errorf("validation error field error: %s", vErr.Field)
+	}
+	if vErr.Index != 0 {
+		t.Errorf("validation error index error: %d", vErr.Index)
+	}
+
+	c = newTestContext("POST", "/json", "application/json", strings.NewReader("{"))
+
+	_, _, err = BindJsonMap(c, rules)
+	var pErr *ParseError
+	if !errors.As(err, &pErr) {
+		t.Fatalf("parse error type error: %T", err)
+	}
+}
+
+// 测试 WithFilters 规则的校验器名称
+func TestWithFilters(t *testing.T) {
+
+	gradeFilters := []Filter{
+		NewFilter("grade", Required(), NewRule("GradeRange", V.Between(1, 12), 1, 12)),
+		NewFilter("items.*.qty", Optional(), Int(), Between(1, 10)),
+	}
+	c := newTestContext("POST", "/json", "application/json", strings.NewReader(`{"grade":13}`))
+	_, _, err := BindJsonMap(c, nil, WithFilters(gradeFilters...))
+	var vErr *ValidationError
+	if !errors.As(err, &vErr) || vErr.Field != "grade" || vErr.Rule != "GradeRange" || vErr.Index != 1 {
+		t.Errorf("custom rule error: %+v", vErr)
+	}
+
+	// 通配符展开的规则使用原规则的校验器名称，WithFilters 的规则在传入的规则之后执行
+	c = newTestContext("POST", "/json", "application/json", strings.NewReader(`{"name":"a","grade":1,"items":[{"qty":1},{"qty":11}]}`))
+	_, _, err = BindJsonMap(c, multiRules[:1], WithFilters(gradeFilters...))
+	if !errors.As(err, &vErr) || vErr.Field != "items.1.qty" || vErr.Rule != "Between" || vErr.Index != 2 {
+		t.Errorf("wildcard rule error: %+v", vErr)
+	}
+}
+
+// 测试收集全部校验失败的参数
+func TestCollectAll(t *testing.T) {
+
+	s1 := map[string]interface{}{
+		"ids":      "1,2,3",
+		"grade":    2,
+		"subjects": []int{3, 4, 12},
+		"ctime":    time.Now().Format("2006-01-02 15:04:05"),
+		"email":    "liumurong1",
+		"phone":    "15810562936",
+		"stat":     3,
+		"school":   1,
+		"cname":    []string{"a", "b", "c"},
+	}
+	s1Byte, _ := json.Marshal(s1)
+
+	c := newTestContext("POST", "/json", "application/json", bytes.NewReader(s1Byte))
+
+	var out SlideResp
+	_, err := BindJsonStruct(c, rules, &out, WithCollectAll())
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("collect all error type error: %T", err)
+	}
+	if len(errs) != 2 || errs[0].Field != "name" || errs[1].Field != "email" {
+		t.Errorf("collect all error fields error: %v", errs)
+	}
+	if out.Grade != 0 {
+		t.Error("struct decoded after validation failed")
+	}
+
+	// 后续规则校验前一个规则的结果
+	chainRules := []V.Filter{
+		R.NewFilter("x-data-id", []V.Validator{V.Optional(7), V.Int(), V.ResetKey("data")}),
+		R.NewFilter("data", []V.Validator{V.Required(), V.Between(1, 10)}),
+		R.NewFilter("page", []V.Validator{V.Optional(101)}),
+	}
+	c = newTestContext("POST", "/json", "application/json", strings.NewReader(`{}`))
+	res, _, err := BindJsonMap(c, chainRules, WithCollectAll())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(res["data"]) != "7" || fmt.Sprint(res["page"]) != "101" {
+		t.Errorf("collect all result error: %v", res)
+	}
+}
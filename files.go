@@ -0,0 +1,246 @@
+package ginvalidate
+
+import (
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/rumis/govalidate/validator"
+)
+
+// 上传文件校验失败的错误码
+const (
+	ErrCodeFileRequired int32 = 4001 + iota
+	ErrCodeFileSize
+	ErrCodeFileExt
+	ErrCodeFileMIME
+	ErrCodeFileCount
+)
+
+// errorType 校验器返回的错误类型
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// NewFileValidator 将上传文件的检查转为govalidate的校验器，检查参数中KEY对应的 *multipart.FileHeader
+// 可与其它校验器放在同一个 validator.Filter 中按顺序执行
+// 校验器的返回值由govalidate的校验器生成：检查通过时为 validator.Optional() 对存在KEY的参数的结果，
+// 检查失败时为 validator.Required() 对缺少KEY的参数的结果，并替换其中的错误码与错误
+func NewFileValidator(check func(key string, files []*multipart.FileHeader) (int32, error)) validator.Validator {
+	pass, fail := reflect.ValueOf(validator.Optional()), reflect.ValueOf(validator.Required())
+	return reflect.MakeFunc(pass.Type(), func(args []reflect.Value) []reflect.Value {
+		key, value := fileValidatorArgs(args)
+		errCode, err := check(key, toFileHeaders(value))
+		if err == nil {
+			return restoreParams(callValidator(pass, replaceParams(args, key, true)), args)
+		}
+		out := restoreParams(callValidator(fail, replaceParams(args, key, false)), args)
+		for i, ov := range out {
+			switch {
+			case ov.Type() == errorType:
+				out[i] = reflect.ValueOf(&err).Elem()
+			case ov.Kind() == reflect.Int32:
+				out[i] = reflect.ValueOf(errCode).Convert(ov.Type())
+			}
+		}
+		return out
+	}).Interface().(validator.Validator)
+}
+
+// fileValidatorArgs 从校验器的参数中取参数KEY及其对应的值
+func fileValidatorArgs(args []reflect.Value) (string, interface{}) {
+	key, found := "", false
+	for _, a := range args {
+		if a.Kind() == reflect.String && !found {
+			key, found = a.String(), true
+		}
+	}
+	for _, a := range args {
+		if a.Kind() != reflect.Map || a.Type().Key().Kind() != reflect.String || a.IsNil() {
+			continue
+		}
+		if v := a.MapIndex(reflect.ValueOf(key).Convert(a.Type().Key())); v.IsValid() {
+			return key, v.Interface()
+		}
+	}
+	return key, nil
+}
+
+// replaceParams 将校验器参数中的参数map替换为新的map，exist为true时新的map中存在KEY
+// 生成返回值的govalidate校验器不读取也不修改实际的参数
+func replaceParams(args []reflect.Value, key string, exist bool) []reflect.Value {
+	res := make([]reflect.Value, len(args))
+	for i, a := range args {
+		res[i] = a
+		if a.Kind() != reflect.Map || a.Type().Key().Kind() != reflect.String {
+			continue
+		}
+		m := reflect.MakeMap(a.Type())
+		if exist {
+			m.SetMapIndex(reflect.ValueOf(key).Convert(a.Type().Key()), reflect.Zero(a.Type().Elem()))
+		}
+		res[i] = m
+	}
+	return res
+}
+
+// restoreParams 将返回值中替换后的参数map还原为实际的参数
+func restoreParams(out []reflect.Value, args []reflect.Value) []reflect.Value {
+	for _, a := range args {
+		if a.Kind() != reflect.Map {
+			continue
+		}
+		for i, ov := range out {
+			if ov.Type() == a.Type() {
+				out[i] = a
+			}
+		}
+	}
+	return out
+}
+
+// callValidator 调用校验器，兼容可变参数的校验器类型
+func callValidator(fn reflect.Value, args []reflect.Value) []reflect.Value {
+	if fn.Type().IsVariadic() {
+		return fn.CallSlice(args)
+	}
+	return fn.Call(args)
+}
+
+// FileRequired 必须上传文件
+func FileRequired() Rule {
+	return NewRule("FileRequired", NewFileValidator(func(key string, files []*multipart.FileHeader) (int32, error) {
+		if len(files) == 0 {
+			return ErrCodeFileRequired, fmt.Errorf("%s: file is required", key)
+		}
+		return 0, nil
+	}))
+}
+
+// FileMaxSize 单个文件的最大字节数
+func FileMaxSize(size int64) Rule {
+	return NewRule("FileMaxSize", NewFileValidator(func(key string, files []*multipart.FileHeader) (int32, error) {
+		for _, fh := range files {
+			if fh.Size > size {
+				return ErrCodeFileSize, fmt.Errorf("%s: file %s exceeds %d bytes", key, fh.Filename, size)
+			}
+		}
+		return 0, nil
+	}), size)
+}
+
+// FileExt 允许的文件扩展名，不区分大小写，如 ".jpg"、"png"
+func FileExt(exts ...string) Rule {
+	allowed := make(map[string]bool, len(exts))
+	args := make([]interface{}, 0, len(exts))
+	for _, ext := range exts {
+		args = append(args, ext)
+		ext = strings.ToLower(ext)
+		if !strings.HasPrefix(ext, ".") {
+			ext = "." + ext
+		}
+		allowed[ext] = true
+	}
+	return NewRule("FileExt", NewFileValidator(func(key string, files []*multipart.FileHeader) (int32, error) {
+		for _, fh := range files {
+			if !allowed[strings.ToLower(filepath.Ext(fh.Filename))] {
+				return ErrCodeFileExt, fmt.Errorf("%s: file %s extension not allowed", key, fh.Filename)
+			}
+		}
+		return 0, nil
+	}), args...)
+}
+
+// FileMIME 允许的文件类型，根据文件内容识别，支持 "image/*" 形式的通配
+func FileMIME(types ...string) Rule {
+	args := make([]interface{}, 0, len(types))
+	for _, t := range types {
+		args = append(args, t)
+	}
+	return NewRule("FileMIME", NewFileValidator(func(key string, files []*multipart.FileHeader) (int32, error) {
+		for _, fh := range files {
+			mime, err := sniffMIME(fh)
+			if err != nil {
+				return ErrCodeFileMIME, fmt.Errorf("%s: read file %s error: %w", key, fh.Filename, err)
+			}
+			if !matchMIME(mime, types) {
+				return ErrCodeFileMIME, fmt.Errorf("%s: file %s type %s not allowed", key, fh.Filename, mime)
+			}
+		}
+		return 0, nil
+	}), args...)
+}
+
+// FileCount 文件数量范围
+func FileCount(min, max int) Rule {
+	return NewRule("FileCount", NewFileValidator(func(key string, files []*multipart.FileHeader) (int32, error) {
+		if len(files) < min || len(files) > max {
+			return ErrCodeFileCount, fmt.Errorf("%s: file count must between %d and %d", key, min, max)
+		}
+		return 0, nil
+	}), min, max)
+}
+
+// toFileHeaders 将参数中的文件统一转为切片
+func toFileHeaders(v interface{}) []*multipart.FileHeader {
+	switch fv := v.(type) {
+	case *multipart.FileHeader:
+		return []*multipart.FileHeader{fv}
+	case []*multipart.FileHeader:
+		return fv
+	}
+	return nil
+}
+
+// sniffMIME 根据文件内容识别文件类型
+func sniffMIME(fh *multipart.FileHeader) (string, error) {
+	f, err := fh.Open()
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	buf := make([]byte, 512)
+	n, err := f.Read(buf)
+	if err != nil && n == 0 && fh.Size > 0 {
+		return "", err
+	}
+	return http.DetectContentType(buf[:n]), nil
+}
+
+// matchMIME 判断文件类型是否在允许的范围内
+func matchMIME(mime string, types []string) bool {
+	mime = strings.TrimSpace(strings.Split(mime, ";")[0])
+	for _, t := range types {
+		t = strings.ToLower(t)
+		if t == mime {
+			return true
+		}
+		if strings.HasSuffix(t, "/*") && strings.HasPrefix(mime, t[:len(t)-1]) {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	fileHeaderType      = reflect.TypeOf(multipart.FileHeader{})
+	fileHeaderSliceType = reflect.TypeOf([]*multipart.FileHeader{})
+)
+
+// fileHeaderHookFunc 在 *multipart.FileHeader 与 []*multipart.FileHeader 字段间转换上传的文件
+func fileHeaderHookFunc() func(reflect.Type, reflect.Type, interface{}) (interface{}, error) {
+	return func(from reflect.Type, to reflect.Type, data interface{}) (interface{}, error) {
+		switch to {
+		case fileHeaderType:
+			if files, ok := data.([]*multipart.FileHeader); ok && len(files) > 0 {
+				return files[0], nil
+			}
+		case fileHeaderSliceType:
+			if fh, ok := data.(*multipart.FileHeader); ok {
+				return []*multipart.FileHeader{fh}, nil
+			}
+		}
+		return data, nil
+	}
+}
@@ -0,0 +1,78 @@
+package ginvalidate
+
+import (
+	"bytes"
+	"errors"
+	"mime/multipart"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	R "github.com/rumis/govalidate"
+	V "github.com/rumis/govalidate/validator"
+)
+
+type UploadReq struct {
+	Name   string                  `json:"name"`
+	Avatar *multipart.FileHeader   `json:"avatar"`
+	Photos []*multipart.FileHeader `json:"photos"`
+}
+
+var pngContent = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func newUploadContext() *gin.Context {
+	buf := new(bytes.Buffer)
+	writer := multipart.NewWriter(buf)
+	writer.WriteField("name", "课件")
+	for _, f := range []struct{ field, name string }{
+		{"avatar", "a.png"},
+		{"photos[]", "b.png"},
+		{"photos[]", "c.png"},
+	} {
+		fw, _ := writer.CreateFormFile(f.field, f.name)
+		fw.Write(pngContent)
+	}
+	writer.Close()
+
+	return newTestContext("POST", "/upload", writer.FormDataContentType(), buf)
+}
+
+func TestBindFiles(t *testing.T) {
+
+	c := newUploadContext()
+	var out UploadReq
+	// 上传文件的校验器与其它校验器在同一组govalidate规则中执行
+	fileRules := append(multiRules[:1:1],
+		R.NewFilter("avatar", []V.Validator{FileRequired().Validator, FileMaxSize(1024).Validator, FileExt("png").Validator, FileMIME("image/*").Validator}),
+		R.NewFilter("photos", []V.Validator{FileCount(1, 3).Validator}),
+	)
+	_, err := BindFormStruct(c, fileRules, &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.Avatar == nil || out.Avatar.Filename != "a.png" {
+		t.Errorf("avatar decode error: %v", out.Avatar)
+	}
+	if len(out.Photos) != 2 {
+		t.Errorf("photos decode error: %v", out.Photos)
+	}
+	f, err := out.Avatar.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	c = newUploadContext()
+	_, err = BindFormStruct(c, multiRules[:1], &out, WithFilters(
+		Filter{Key: "avatar", Rules: []Rule{FileExt(".jpg")}},
+		Filter{Key: "grade", Rules: []Rule{Required()}},
+		Filter{Key: "cover", Rules: []Rule{FileMaxSize(1024), FileRequired()}},
+	), WithCollectAll())
+	var errs ValidationErrors
+	if !errors.As(err, &errs) || len(errs) != 3 {
+		t.Fatalf("file validation error: %v", err)
+	}
+	// 按规则顺序返回，未上传的文件通过 FileMaxSize 后继续执行 FileRequired
+	if errs[0].Code != ErrCodeFileExt || errs[1].Field != "grade" || errs[2].Code != ErrCodeFileRequired || errs[2].Rule != "FileRequired" || errs[2].Index != 1 {
+		t.Errorf("file validation error code: %v", errs)
+	}
+}
@@ -0,0 +1,152 @@
+package ginvalidate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+
+	"github.com/BurntSushi/toml"
+	"github.com/gin-gonic/gin"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// MIMEYAML YAML请求体的Content-Type，同时支持 application/x-yaml 与 text/yaml
+	MIMEYAML = "application/yaml"
+	// MIMETOML TOML请求体的Content-Type
+	MIMETOML = "application/toml"
+)
+
+// bodyDecoder 将请求体转为map
+type bodyDecoder func(r io.Reader) (map[string]interface{}, error)
+
+// collectBody 使用decode解析请求体，并合并路径参数与header参数
+func collectBody(c *gin.Context, o *options, decode bodyDecoder) (map[string]interface{}, error) {
+	defer c.Request.Body.Close()
+	// 解析body
+	tmpRes, err := decode(c.Request.Body)
+	if err != nil {
+		return tmpRes, err
+	}
+	// 合并路径参数与header参数
+	mergeParams(c, tmpRes, o)
+	return tmpRes, nil
+}
+
+// collectYAML 解析YAML参数
+func collectYAML(c *gin.Context, o *options) (map[string]interface{}, error) {
+	return collectBody(c, o, decodeYAML)
+}
+
+// collectTOML 解析TOML参数
+func collectTOML(c *gin.Context, o *options) (map[string]interface{}, error) {
+	return collectBody(c, o, decodeTOML)
+}
+
+// decodeYAML 将YAML文档转为map，空body返回空map
+func decodeYAML(r io.Reader) (map[string]interface{}, error) {
+	var v interface{}
+	err := yaml.NewDecoder(r).Decode(&v)
+	if errors.Is(err, io.EOF) {
+		return make(map[string]interface{}), nil
+	}
+	if err != nil {
+		return make(map[string]interface{}), err
+	}
+	return toParamsMap(normalizeValue(v))
+}
+
+// decodeTOML 将TOML文档转为map
+func decodeTOML(r io.Reader) (map[string]interface{}, error) {
+	var v map[string]interface{}
+	if _, err := toml.NewDecoder(r).Decode(&v); err != nil {
+		return make(map[string]interface{}), err
+	}
+	return toParamsMap(normalizeValue(v))
+}
+
+// toParamsMap 请求体的顶层须为对象
+func toParamsMap(v interface{}) (map[string]interface{}, error) {
+	switch m := v.(type) {
+	case nil:
+		return make(map[string]interface{}), nil
+	case map[string]interface{}:
+		return m, nil
+	}
+	return make(map[string]interface{}), fmt.Errorf("request body must be an object, got %T", v)
+}
+
+// normalizeValue 将解码结果转为与 BindJsonMap 一致的形式
+// map的KEY转为字符串，切片转为 []interface{}，数字转为 json.Number
+func normalizeValue(v interface{}) interface{} {
+	switch tv := v.(type) {
+	case map[interface{}]interface{}:
+		res := make(map[string]interface{}, len(tv))
+		for k, ev := range tv {
+			res[mapKey(k)] = normalizeValue(ev)
+		}
+		return res
+	case map[string]interface{}:
+		res := make(map[string]interface{}, len(tv))
+		for k, ev := range tv {
+			res[k] = normalizeValue(ev)
+		}
+		return res
+	case []interface{}:
+		res := make([]interface{}, len(tv))
+		for i, ev := range tv {
+			res[i] = normalizeValue(ev)
+		}
+		return res
+	case []map[string]interface{}:
+		res := make([]interface{}, len(tv))
+		for i, ev := range tv {
+			res[i] = normalizeValue(ev)
+		}
+		return res
+	case int:
+		return json.Number(strconv.FormatInt(int64(tv), 10))
+	case int8:
+		return json.Number(strconv.FormatInt(int64(tv), 10))
+	case int16:
+		return json.Number(strconv.FormatInt(int64(tv), 10))
+	case int32:
+		return json.Number(strconv.FormatInt(int64(tv), 10))
+	case int64:
+		return json.Number(strconv.FormatInt(tv, 10))
+	case uint:
+		return json.Number(strconv.FormatUint(uint64(tv), 10))
+	case uint8:
+		return json.Number(strconv.FormatUint(uint64(tv), 10))
+	case uint16:
+		return json.Number(strconv.FormatUint(uint64(tv), 10))
+	case uint32:
+		return json.Number(strconv.FormatUint(uint64(tv), 10))
+	case uint64:
+		return json.Number(strconv.FormatUint(tv, 10))
+	case float32:
+		return floatNumber(float64(tv), 32)
+	case float64:
+		return floatNumber(tv, 64)
+	}
+	return v
+}
+
+// mapKey 将map的KEY转为字符串
+func mapKey(k interface{}) string {
+	if b, ok := k.([]byte); ok {
+		return string(b)
+	}
+	return fmt.Sprint(normalizeValue(k))
+}
+
+// floatNumber 浮点数转为 json.Number，NaN与Inf无法以JSON表示，保持原值
+func floatNumber(f float64, bitSize int) interface{} {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return f
+	}
+	return json.Number(strconv.FormatFloat(f, 'f', -1, bitSize))
+}
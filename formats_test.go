@@ -0,0 +1,80 @@
+package ginvalidate
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDecodeYAML(t *testing.T) {
+
+	doc := `
+name: 课件
+grade: 2
+score: 9.5
+subjects: [3, 4, 12]
+school:
+  id: 1
+  tags: [a, b]
+`
+	res, err := decodeYAML(strings.NewReader(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{
+		"name":     "课件",
+		"grade":    json.Number("2"),
+		"score":    json.Number("9.5"),
+		"subjects": []interface{}{json.Number("3"), json.Number("4"), json.Number("12")},
+		"school": map[string]interface{}{
+			"id":   json.Number("1"),
+			"tags": []interface{}{"a", "b"},
+		},
+	}
+	if !reflect.DeepEqual(res, want) {
+		t.Errorf("decode yaml error: %v", res)
+	}
+
+	if _, err := decodeYAML(strings.NewReader("- 1\n- 2\n")); err == nil {
+		t.Error("yaml sequence body should fail")
+	}
+}
+
+func TestDecodeTOML(t *testing.T) {
+
+	doc := `
+name = "课件"
+grade = 2
+subjects = [3, 4, 12]
+
+[[items]]
+qty = 1
+`
+	res, err := decodeTOML(strings.NewReader(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{
+		"name":     "课件",
+		"grade":    json.Number("2"),
+		"subjects": []interface{}{json.Number("3"), json.Number("4"), json.Number("12")},
+		"items":    []interface{}{map[string]interface{}{"qty": json.Number("1")}},
+	}
+	if !reflect.DeepEqual(res, want) {
+		t.Errorf("decode toml error: %v", res)
+	}
+}
+
+func TestBindYaml(t *testing.T) {
+
+	c := newTestContext("POST", "/yaml", "application/x-yaml", strings.NewReader("name: 课件\nids: 1,2,3\n"))
+
+	res, _, err := Bind(c, multiRules)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res["name"] != "课件" || res["ids"] != "1,2,3" {
+		t.Errorf("bind yaml error: %v", res)
+	}
+}
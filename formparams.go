@@ -1,5 +1,17 @@
 package ginvalidate
 
+import (
+	"mime/multipart"
+	"strconv"
+	"strings"
+)
+
+// nestedIndexLimit 中括号中的数字下标小于该值时作为切片下标，否则作为map的KEY
+const nestedIndexLimit = 20
+
+// nestedSlotLimit 一个请求中按数字下标补齐切片时最多分配的元素数量，超出后数字下标作为map的KEY
+const nestedSlotLimit = 1000
+
 type ParamsCollection map[string]interface{}
 
 func NewParamsCollection() ParamsCollection {
@@ -8,23 +20,42 @@ func NewParamsCollection() ParamsCollection {
 
 // Set 设置值
 func (pc ParamsCollection) Set(k string, v []string) {
+	pc[k] = mergeValues(pc[k], v)
+}
+
+// SetNested 按中括号表示的层级设置值，如 filter[owner][id] 设置为 filter 下 owner 下的 id
+// 数字下标如 items[0][name] 设置为切片的元素，空下标如 ids[] 向切片追加
+// 不符合中括号格式的KEY与 Set 相同
+func (pc ParamsCollection) SetNested(k string, v []string) {
+	slots := nestedSlotLimit
+	pc.setNestedSlots(k, v, &slots)
+}
+
+// setNestedSlots 与 SetNested 相同，slots为剩余可分配的切片元素数量
+func (pc ParamsCollection) setNestedSlots(k string, v []string, slots *int) {
+	path, ok := splitBracketKey(k)
+	if !ok || len(path) == 1 {
+		pc.Set(k, v)
+		return
+	}
+	pc[path[0]] = setNested(pc[path[0]], path[1:], v, slots)
+}
+
+// SetFiles 设置上传的文件
+func (pc ParamsCollection) SetFiles(k string, v []*multipart.FileHeader) {
 	ev, ok := pc[k]
 	if !ok {
 		if len(v) == 1 {
-			pc[k] = v[0] // 如果参数为单值，则直接赋值一个字符串
+			pc[k] = v[0] // 如果为单个文件，则直接赋值
 			return
 		}
-		pc[k] = v // 如果参数为多值，则直接赋值为数组
+		pc[k] = v // 如果为多个文件，则直接赋值为数组
 		return
 	}
 	switch eVal := ev.(type) {
-	case string:
-		if len(v) == 1 {
-			pc[k] = []string{eVal, v[0]}
-			return
-		}
-		pc[k] = append([]string{eVal}, v...)
-	case []string:
+	case *multipart.FileHeader:
+		pc[k] = append([]*multipart.FileHeader{eVal}, v...)
+	case []*multipart.FileHeader:
 		pc[k] = append(eVal, v...)
 	}
 }
@@ -33,3 +64,110 @@ func (pc ParamsCollection) Set(k string, v []string) {
 func (pc ParamsCollection) To() map[string]interface{} {
 	return pc
 }
+
+// mergeValues 合并同名参数的值
+func mergeValues(ev interface{}, v []string) interface{} {
+	switch eVal := ev.(type) {
+	case nil:
+		if len(v) == 1 {
+			return v[0] // 如果参数为单值，则直接赋值一个字符串
+		}
+		return v // 如果参数为多值，则直接赋值为数组
+	case string:
+		if len(v) == 1 {
+			return []string{eVal, v[0]}
+		}
+		return append([]string{eVal}, v...)
+	case []string:
+		return append(eVal, v...)
+	}
+	return ev
+}
+
+// splitBracketKey 将 a[b][0] 拆分为 a、b、0，格式不符时返回false
+func splitBracketKey(k string) ([]string, bool) {
+	i := strings.IndexByte(k, '[')
+	if i <= 0 {
+		return []string{k}, i < 0
+	}
+	path := []string{k[:i]}
+	rest := k[i:]
+	for rest != "" {
+		end := strings.IndexByte(rest, ']')
+		if rest[0] != '[' || end < 0 {
+			return nil, false
+		}
+		path = append(path, rest[1:end])
+		rest = rest[end+1:]
+	}
+	return path, true
+}
+
+// setNested 在node下按path设置值，返回设置后的node
+func setNested(node interface{}, path []string, v []string, slots *int) interface{} {
+	if len(path) == 0 {
+		return mergeValues(node, v)
+	}
+	seg, rest := path[0], path[1:]
+	if seg == "" {
+		s, ok := node.([]interface{})
+		if !ok && node != nil {
+			if m, isMap := node.(map[string]interface{}); isMap {
+				m[strconv.Itoa(len(m))] = setNested(nil, rest, v, slots)
+				return m
+			}
+			s = toInterfaces(node)
+		}
+		if len(rest) > 0 {
+			// 每个值追加为一个元素，如 items[][name]=a&items[][name]=b
+			for _, sv := range v {
+				s = append(s, setNested(nil, rest, []string{sv}, slots))
+			}
+			return s
+		}
+		for _, sv := range v {
+			s = append(s, sv)
+		}
+		return s
+	}
+	if idx, err := strconv.Atoi(seg); err == nil && idx >= 0 && idx < nestedIndexLimit {
+		s, ok := node.([]interface{})
+		if (ok || node == nil) && idx-len(s) < *slots {
+			for len(s) <= idx {
+				s = append(s, nil)
+				*slots--
+			}
+			s[idx] = setNested(s[idx], rest, v, slots)
+			return s
+		}
+	}
+	m, ok := node.(map[string]interface{})
+	if !ok {
+		m = make(map[string]interface{})
+		// 切片转为以下标为KEY的map
+		if s, isSlice := node.([]interface{}); isSlice {
+			for i, sv := range s {
+				if sv != nil {
+					m[strconv.Itoa(i)] = sv
+				}
+			}
+		}
+	}
+	m[seg] = setNested(m[seg], rest, v, slots)
+	return m
+}
+
+// toInterfaces 将参数值转为 []interface{}
+func toInterfaces(v interface{}) []interface{} {
+	switch tv := v.(type) {
+	case string:
+		return []interface{}{tv}
+	case []string:
+		res := make([]interface{}, len(tv))
+		for i, sv := range tv {
+			res[i] = sv
+		}
+		return res
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package ginvalidate
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSetNested(t *testing.T) {
+
+	pCol := NewParamsCollection()
+	pCol.SetNested("filter[status]", []string{"1"})
+	pCol.SetNested("filter[owner][id]", []string{"5"})
+	pCol.SetNested("items[1][name]", []string{"b"})
+	pCol.SetNested("items[0][name]", []string{"a"})
+	pCol.SetNested("ids[]", []string{"1", "2"})
+	pCol.SetNested("tags[][name]", []string{"x", "y"})
+	pCol.SetNested("plain", []string{"p"})
+	pCol.SetNested("bad[key", []string{"k"})
+
+	want := map[string]interface{}{
+		"filter": map[string]interface{}{
+			"status": "1",
+			"owner":  map[string]interface{}{"id": "5"},
+		},
+		"items": []interface{}{
+			map[string]interface{}{"name": "a"},
+			map[string]interface{}{"name": "b"},
+		},
+		"ids": []interface{}{"1", "2"},
+		"tags": []interface{}{
+			map[string]interface{}{"name": "x"},
+			map[string]interface{}{"name": "y"},
+		},
+		"plain":   "p",
+		"bad[key": "k",
+	}
+	if !reflect.DeepEqual(pCol.To(), want) {
+		t.Errorf("set nested error: %v", pCol.To())
+	}
+
+	// 超出下标上限或切片元素数量上限时作为map的KEY
+	pCol = NewParamsCollection()
+	pCol.SetNested("big[999]", []string{"x"})
+	slots := 3
+	pCol.setNestedSlots("a[2]", []string{"a"}, &slots)
+	pCol.setNestedSlots("b[0]", []string{"b"}, &slots)
+	want = map[string]interface{}{
+		"big": map[string]interface{}{"999": "x"},
+		"a":   []interface{}{nil, nil, "a"},
+		"b":   map[string]interface{}{"0": "b"},
+	}
+	if !reflect.DeepEqual(pCol.To(), want) {
+		t.Errorf("set nested limit error: %v", pCol.To())
+	}
+}
+
+func TestBindNestedKeys(t *testing.T) {
+
+	c := newTestContext("GET", "/query?name=课件&ids=1&filter[status]=1&filter[owner][id]=5&items[0][name]=a&items[1][name]=b", "", nil)
+
+	var out struct {
+		Name   string `json:"name"`
+		Filter struct {
+			Status string `json:"status"`
+			Owner  struct {
+				ID string `json:"id"`
+			} `json:"owner"`
+		} `json:"filter"`
+		Items []struct {
+			Name string `json:"name"`
+		} `json:"items"`
+	}
+	if _, err := BindQueryStruct(c, multiRules, &out, WithNestedKeys()); err != nil {
+		t.Fatal(err)
+	}
+	if out.Filter.Status != "1" || out.Filter.Owner.ID != "5" || len(out.Items) != 2 || out.Items[1].Name != "b" {
+		t.Errorf("bind nested keys error: %+v", out)
+	}
+
+	res, _, err := BindQueryMap(c, multiRules)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := res["filter[status]"]; !ok {
+		t.Errorf("nested keys should be opt-in: %v", res)
+	}
+}
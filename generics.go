@@ -0,0 +1,70 @@
+package ginvalidate
+
+import (
+	"reflect"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rumis/govalidate/validator"
+)
+
+// BindAs 根据请求方法与Content-Type解析并校验参数，返回T类型的对象
+// T 可为结构体或结构体指针，失败时返回T的零值与 BindStruct 相同类型的错误
+func BindAs[T any](c *gin.Context, rules []validator.Filter, opts ...Option) (T, error) {
+	var res T
+	if _, err := BindStruct(c, rules, target(&res), opts...); err != nil {
+		var zero T
+		return zero, err
+	}
+	return res, nil
+}
+
+// BindWith 从指定的参数来源解析并校验参数，返回T类型的对象
+func BindWith[T any](c *gin.Context, src Source, rules []validator.Filter, opts ...Option) (T, error) {
+	return BindAs[T](c, rules, withOptions(opts, WithSource(src))...)
+}
+
+// BindAutoAs 根据T的 validate tag 解析并校验参数，返回T类型的对象
+func BindAutoAs[T any](c *gin.Context, opts ...Option) (T, error) {
+	var res T
+	fs, err := filtersFor(res)
+	if err != nil {
+		return res, err
+	}
+	return BindAs[T](c, nil, withOptions(opts, WithFilters(fs...))...)
+}
+
+// BindSliceAs 解析顶层为数组的JSON参数，返回T类型的对象切片
+// BatchPartial 时同时返回切片与校验失败的错误，校验失败的元素为零值
+func BindSliceAs[T any](c *gin.Context, rules []validator.Filter, opts ...Option) ([]T, error) {
+	var res []T
+	_, err := BindJsonStructSlice(c, rules, &res, opts...)
+	return res, err
+}
+
+// GetObjectAs 获取中间件转换后的对象，T 为 WithObject 注册的类型或其指针
+func GetObjectAs[T any](c *gin.Context) (T, bool) {
+	var res T
+	v, ok := c.Get(ObjectKey)
+	if !ok {
+		return res, false
+	}
+	if obj, ok := v.(T); ok {
+		return obj, true
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return res, false
+	}
+	res, ok = rv.Elem().Interface().(T)
+	return res, ok
+}
+
+// target T为指针类型时创建指向的对象，返回可供mapDecode写入的指针
+func target[T any](p *T) interface{} {
+	rv := reflect.ValueOf(p).Elem()
+	if rv.Kind() == reflect.Ptr {
+		rv.Set(reflect.New(rv.Type().Elem()))
+		return rv.Interface()
+	}
+	return p
+}
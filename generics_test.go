@@ -0,0 +1,66 @@
+package ginvalidate
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type typedReq struct {
+	Name string `json:"name" validate:"required"`
+	Ids  string `json:"ids" validate:"required,dotint"`
+}
+
+func TestBindAs(t *testing.T) {
+
+	newContext := func(body string) *gin.Context {
+		return newTestContext("POST", "/json", "application/json", strings.NewReader(body))
+	}
+
+	req, err := BindAs[typedReq](newContext(`{"name":"课件","ids":"1,2"}`), multiRules)
+	if err != nil || req.Name != "课件" || req.Ids != "1,2" {
+		t.Errorf("bind as error: %v %+v", err, req)
+	}
+
+	p, err := BindWith[*typedReq](newContext(`{"name":"课件","ids":"1"}`), SourceJSON, multiRules)
+	if err != nil || p == nil || p.Ids != "1" {
+		t.Errorf("bind with pointer error: %v %+v", err, p)
+	}
+
+	p, err = BindAutoAs[*typedReq](newContext(`{"name":"课件"}`))
+	var vErr *ValidationError
+	if !errors.As(err, &vErr) || vErr.Field != "ids" || p != nil {
+		t.Errorf("bind auto as error: %v %+v", err, p)
+	}
+
+	items, err := BindSliceAs[typedReq](newContext(`[{"name":"a","ids":"1"},{"name":"b"}]`), multiRules, WithBatchPolicy(BatchPartial))
+	if err == nil || len(items) != 2 || items[0].Name != "a" {
+		t.Errorf("bind slice as error: %v %+v", err, items)
+	}
+}
+
+func TestGetObjectAs(t *testing.T) {
+
+	r := gin.New()
+	r.POST("/typed", Middleware(multiRules, WithObject(typedReq{})), func(c *gin.Context) {
+		v, ok := GetObjectAs[typedReq](c)
+		p, pok := GetObjectAs[*typedReq](c)
+		if !ok || !pok || v.Name != p.Name {
+			c.String(http.StatusInternalServerError, "object not found")
+			return
+		}
+		c.String(http.StatusOK, v.Name)
+	})
+
+	req := httptest.NewRequest("POST", "/typed", strings.NewReader(`{"name":"课件","ids":"1"}`))
+	req.Header.Add("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusOK || w.Body.String() != "课件" {
+		t.Errorf("get object as error: %d %s", w.Code, w.Body.String())
+	}
+}
@@ -2,342 +2,334 @@ package ginvalidate
 
 import (
 	"context"
-	"encoding/json"
-	"errors"
-	"io"
-	"strings"
 
 	"github.com/gin-gonic/gin"
-	"github.com/rumis/govalidate"
 	"github.com/rumis/govalidate/validator"
 )
 
 // BindJsonMap 解析请求参数
 // Content-type:application/json
-func BindJsonMap(c *gin.Context, rules []validator.Filter) (map[string]interface{}, int32, error) {
-	defer c.Request.Body.Close()
-
-	// 解析body
-	decoder := json.NewDecoder(c.Request.Body)
-	decoder.UseNumber()
-	tmpRes := make(map[string]interface{})
-	err := decoder.Decode(&tmpRes)
-	if err != nil && !errors.Is(err, io.EOF) {
-		return tmpRes, 0, err
-	}
-	// 解析header参数
-	for k, v := range c.Request.Header {
-		tmpRes[strings.ToLower(k)] = strings.Join(v, ",")
-	}
-	// 校验
-	res, errCode, err := govalidate.Validate(tmpRes, rules)
-	if err != nil {
-		return tmpRes, 0, err
-	}
-	return res, errCode, nil
+func BindJsonMap(c *gin.Context, rules []validator.Filter, opts ...Option) (map[string]interface{}, int32, error) {
+	return Bind(c, rules, withOptions(opts, WithSource(SourceJSON))...)
 }
 
 // BindJsonMapContent 解析请求参数
 // Content-type:application/json
-func BindJsonMapContext(c *gin.Context, rules []validator.Filter) (map[string]interface{}, int32, error) {
-	defer c.Request.Body.Close()
-	// 解析body参数
-	decoder := json.NewDecoder(c.Request.Body)
-	decoder.UseNumber()
-	tmpRes := make(map[string]interface{})
-	err := decoder.Decode(&tmpRes)
-	if err != nil && !errors.Is(err, io.EOF) {
-		return tmpRes, 0, err
-	}
-	// 解析header参数
-	for k, v := range c.Request.Header {
-		tmpRes[strings.ToLower(k)] = strings.Join(v, ",")
-	}
-	// 校验
-	res, errCode, err := govalidate.Validate1(toContext(c), tmpRes, rules)
-	if err != nil {
-		return tmpRes, 0, err
-	}
-	return res, errCode, nil
+func BindJsonMapContext(c *gin.Context, rules []validator.Filter, opts ...Option) (map[string]interface{}, int32, error) {
+	return Bind(c, rules, withOptions(opts, WithSource(SourceJSON), WithContext())...)
 }
 
 // BindJsonStruct 返回值为对象
 // Content-type:application/json
-func BindJsonStruct(c *gin.Context, rules []validator.Filter, obj interface{}) (int32, error) {
-	res, errCode, err := BindJsonMap(c, rules)
-	if err != nil {
-		return 0, err
-	}
-	err = mapDecode(res, obj)
-	if err != nil {
-		return 0, err
-	}
-	return errCode, nil
+func BindJsonStruct(c *gin.Context, rules []validator.Filter, obj interface{}, opts ...Option) (int32, error) {
+	return BindStruct(c, rules, obj, withOptions(opts, WithSource(SourceJSON))...)
 }
 
 // BindJsonStructContent 返回值为对象
 // Content-type:application/json
-func BindJsonStructContext(c *gin.Context, rules []validator.Filter, obj interface{}) (int32, error) {
-	res, errCode, err := BindJsonMapContext(c, rules)
-	if err != nil {
-		return 0, err
-	}
-	err = mapDecode(res, obj)
-	if err != nil {
-		return 0, err
-	}
-	return errCode, nil
+func BindJsonStructContext(c *gin.Context, rules []validator.Filter, obj interface{}, opts ...Option) (int32, error) {
+	return BindStruct(c, rules, obj, withOptions(opts, WithSource(SourceJSON), WithContext())...)
 }
 
 // BindJsonStructRaw 返回值为对象
 // 如果校验失败，返回原始数据内容
 // Content-type:application/json
-func BindJsonStructRaw(c *gin.Context, rules []validator.Filter, obj interface{}) (int32, interface{}, error) {
-	res, errCode, err := BindJsonMap(c, rules)
-	if err != nil {
-		return 0, res, err
-	}
-	err = mapDecode(res, obj)
-	if err != nil {
-		return 0, res, err
-	}
-	return errCode, nil, nil
+func BindJsonStructRaw(c *gin.Context, rules []validator.Filter, obj interface{}, opts ...Option) (int32, interface{}, error) {
+	return bindStruct(c, rules, obj, withOptions(opts, WithSource(SourceJSON)))
 }
 
 // BindJsonStructRawContent 返回值为对象
 // 如果校验失败，返回原始数据内容
 // Content-type:application/json
-func BindJsonStructRawContext(c *gin.Context, rules []validator.Filter, obj interface{}) (int32, interface{}, error) {
-	res, errCode, err := BindJsonMapContext(c, rules)
-	if err != nil {
-		return 0, res, err
-	}
-	err = mapDecode(res, obj)
-	if err != nil {
-		return 0, res, err
-	}
-	return errCode, nil, nil
+func BindJsonStructRawContext(c *gin.Context, rules []validator.Filter, obj interface{}, opts ...Option) (int32, interface{}, error) {
+	return bindStruct(c, rules, obj, withOptions(opts, WithSource(SourceJSON), WithContext()))
 }
 
 // BindQueryMap 解析Query部分参数
-func BindQueryMap(c *gin.Context, rules []validator.Filter) (map[string]interface{}, int32, error) {
-	pCol := NewParamsCollection()
-	// 解析查询参数
-	values := c.Request.URL.Query()
-	for k, v := range values {
-		k = FormatKey(k)
-		pCol.Set(k, v)
-	}
-	// 解析header参数
-	for k, v := range c.Request.Header {
-		pCol.Set(strings.ToLower(k), v)
-	}
-	// 校验
-	res, errCode, err := govalidate.Validate(pCol.To(), rules)
-	if err != nil {
-		return res, 0, err
-	}
-	return res, errCode, nil
+func BindQueryMap(c *gin.Context, rules []validator.Filter, opts ...Option) (map[string]interface{}, int32, error) {
+	return Bind(c, rules, withOptions(opts, WithSource(SourceQuery))...)
 }
 
 // BindQueryMapContent 解析Query部分参数
-func BindQueryMapContext(c *gin.Context, rules []validator.Filter) (map[string]interface{}, int32, error) {
-	pCol := NewParamsCollection()
-	// 解析查询参数
-	values := c.Request.URL.Query()
-	for k, v := range values {
-		k = FormatKey(k)
-		pCol.Set(k, v)
-	}
-	// 解析Header参数
-	for k, v := range c.Request.Header {
-		pCol.Set(strings.ToLower(k), v)
-	}
-	// 校验
-	res, errCode, err := govalidate.Validate1(toContext(c), pCol.To(), rules)
-	if err != nil {
-		return res, 0, err
-	}
-	return res, errCode, nil
+func BindQueryMapContext(c *gin.Context, rules []validator.Filter, opts ...Option) (map[string]interface{}, int32, error) {
+	return Bind(c, rules, withOptions(opts, WithSource(SourceQuery), WithContext())...)
 }
 
 // BindQueryStruct 解析Query参数
-func BindQueryStruct(c *gin.Context, rules []validator.Filter, obj interface{}) (int32, error) {
-	res, errCode, err := BindQueryMap(c, rules)
-	if err != nil {
-		return 0, err
-	}
-	err = mapDecode(res, obj)
-	if err != nil {
-		return 0, err
-	}
-	return errCode, nil
+func BindQueryStruct(c *gin.Context, rules []validator.Filter, obj interface{}, opts ...Option) (int32, error) {
+	return BindStruct(c, rules, obj, withOptions(opts, WithSource(SourceQuery))...)
 }
 
 // BindQueryMapContent 解析Query参数
-func BindQueryStructContext(c *gin.Context, rules []validator.Filter, obj interface{}) (int32, error) {
-	res, errCode, err := BindQueryMapContext(c, rules)
-	if err != nil {
-		return 0, err
-	}
-	err = mapDecode(res, obj)
-	if err != nil {
-		return 0, err
-	}
-	return errCode, nil
+func BindQueryStructContext(c *gin.Context, rules []validator.Filter, obj interface{}, opts ...Option) (int32, error) {
+	return BindStruct(c, rules, obj, withOptions(opts, WithSource(SourceQuery), WithContext())...)
 }
 
 // BindQueryStructRaw 解析Query参数
 // 若解析失败，返回原始数据内容
-func BindQueryStructRaw(c *gin.Context, rules []validator.Filter, obj interface{}) (int32, interface{}, error) {
-	res, errCode, err := BindQueryMap(c, rules)
-	if err != nil {
-		return 0, res, err
-	}
-	err = mapDecode(res, obj)
-	if err != nil {
-		return 0, res, err
-	}
-	return errCode, nil, nil
+func BindQueryStructRaw(c *gin.Context, rules []validator.Filter, obj interface{}, opts ...Option) (int32, interface{}, error) {
+	return bindStruct(c, rules, obj, withOptions(opts, WithSource(SourceQuery)))
 }
 
 // BindQueryStructRawContent 解析Query参数
 // 若解析失败，返回原始数据内容
-func BindQueryStructRawContext(c *gin.Context, rules []validator.Filter, obj interface{}) (int32, interface{}, error) {
-	res, errCode, err := BindQueryMapContext(c, rules)
-	if err != nil {
-		return 0, res, err
-	}
-	err = mapDecode(res, obj)
-	if err != nil {
-		return 0, res, err
-	}
-	return errCode, nil, nil
+func BindQueryStructRawContext(c *gin.Context, rules []validator.Filter, obj interface{}, opts ...Option) (int32, interface{}, error) {
+	return bindStruct(c, rules, obj, withOptions(opts, WithSource(SourceQuery), WithContext()))
 }
 
 // BindFormMap 解析form数据
-func BindFormMap(c *gin.Context, rules []validator.Filter) (map[string]interface{}, int32, error) {
-	if err := c.Request.ParseForm(); err != nil {
-		return nil, 0, err
-	}
-	pCol := NewParamsCollection()
-	values := c.Request.PostForm
-	for k, v := range values {
-		k = FormatKey(k)
-		pCol.Set(k, v)
-	}
-
-	// 解析MultipartForm
-	err := c.Request.ParseMultipartForm(10240)
-	if err == nil {
-		for k, v := range c.Request.MultipartForm.Value {
-			k = FormatKey(k)
-			pCol.Set(k, v)
-		}
-	}
-
-	// 解析Header参数
-	for k, v := range c.Request.Header {
-		pCol.Set(strings.ToLower(k), v)
-	}
-
-	res, errCode, err := govalidate.Validate(pCol.To(), rules)
-	if err != nil {
-		return pCol.To(), 0, err
-	}
-	return res, errCode, nil
+func BindFormMap(c *gin.Context, rules []validator.Filter, opts ...Option) (map[string]interface{}, int32, error) {
+	return Bind(c, rules, withOptions(opts, WithSource(SourceForm))...)
 }
 
 // BindFormMapContent 解析form数据
-func BindFormMapContext(c *gin.Context, rules []validator.Filter) (map[string]interface{}, int32, error) {
-	if err := c.Request.ParseForm(); err != nil {
-		return nil, 0, err
-	}
-	// 解析form
-	pCol := NewParamsCollection()
-	values := c.Request.PostForm
-	for k, v := range values {
-		k = FormatKey(k)
-		pCol.Set(k, v)
-	}
-	// 解析MultipartForm
-	err := c.Request.ParseMultipartForm(10240)
-	if err == nil {
-		for k, v := range c.Request.MultipartForm.Value {
-			k = FormatKey(k)
-			pCol.Set(k, v)
-		}
-	}
-	// 解析Header参数
-	for k, v := range c.Request.Header {
-		pCol.Set(strings.ToLower(k), v)
-	}
-	// 校验
-	res, errCode, err := govalidate.Validate1(toContext(c), pCol.To(), rules)
-	if err != nil {
-		return pCol.To(), 0, err
-	}
-	return res, errCode, nil
+func BindFormMapContext(c *gin.Context, rules []validator.Filter, opts ...Option) (map[string]interface{}, int32, error) {
+	return Bind(c, rules, withOptions(opts, WithSource(SourceForm), WithContext())...)
 }
 
 // BindFormStruct 解析Form参数
-func BindFormStruct(c *gin.Context, rules []validator.Filter, obj interface{}) (int32, error) {
-	res, errCode, err := BindFormMap(c, rules)
-	if err != nil {
-		return 0, err
-	}
-	err = mapDecode(res, obj)
-	if err != nil {
-		return 0, err
-	}
-	return errCode, nil
+func BindFormStruct(c *gin.Context, rules []validator.Filter, obj interface{}, opts ...Option) (int32, error) {
+	return BindStruct(c, rules, obj, withOptions(opts, WithSource(SourceForm))...)
 }
 
 // BindFormStructContent 解析Form参数
-func BindFormStructContext(c *gin.Context, rules []validator.Filter, obj interface{}) (int32, error) {
-	res, errCode, err := BindFormMapContext(c, rules)
-	if err != nil {
-		return 0, err
-	}
-	err = mapDecode(res, obj)
-	if err != nil {
-		return 0, err
-	}
-	return errCode, nil
+func BindFormStructContext(c *gin.Context, rules []validator.Filter, obj interface{}, opts ...Option) (int32, error) {
+	return BindStruct(c, rules, obj, withOptions(opts, WithSource(SourceForm), WithContext())...)
 }
 
 // BindFormStructRaw 解析Form参数
 // 若校验失败，返回map格式的原始数据
-func BindFormStructRaw(c *gin.Context, rules []validator.Filter, obj interface{}) (int32, interface{}, error) {
-	res, errCode, err := BindFormMap(c, rules)
-	if err != nil {
-		return 0, res, err
-	}
-	err = mapDecode(res, obj)
-	if err != nil {
-		return 0, res, err
-	}
-	return errCode, nil, nil
+func BindFormStructRaw(c *gin.Context, rules []validator.Filter, obj interface{}, opts ...Option) (int32, interface{}, error) {
+	return bindStruct(c, rules, obj, withOptions(opts, WithSource(SourceForm)))
 }
 
 // BindFormStructRawContent 解析Form参数
 // 若校验失败，返回map格式的原始数据
-func BindFormStructRawContext(c *gin.Context, rules []validator.Filter, obj interface{}) (int32, interface{}, error) {
-	res, errCode, err := BindFormMapContext(c, rules)
-	if err != nil {
-		return 0, res, err
+func BindFormStructRawContext(c *gin.Context, rules []validator.Filter, obj interface{}, opts ...Option) (int32, interface{}, error) {
+	return bindStruct(c, rules, obj, withOptions(opts, WithSource(SourceForm), WithContext()))
+}
+
+// BindXmlMap 解析XML数据
+// Content-type:application/xml
+func BindXmlMap(c *gin.Context, rules []validator.Filter, opts ...Option) (map[string]interface{}, int32, error) {
+	return Bind(c, rules, withOptions(opts, WithSource(SourceXML))...)
+}
+
+// BindXmlMapContext 解析XML数据
+// Content-type:application/xml
+func BindXmlMapContext(c *gin.Context, rules []validator.Filter, opts ...Option) (map[string]interface{}, int32, error) {
+	return Bind(c, rules, withOptions(opts, WithSource(SourceXML), WithContext())...)
+}
+
+// BindXmlStruct 解析XML数据，返回值为对象
+// Content-type:application/xml
+func BindXmlStruct(c *gin.Context, rules []validator.Filter, obj interface{}, opts ...Option) (int32, error) {
+	return BindStruct(c, rules, obj, withOptions(opts, WithSource(SourceXML))...)
+}
+
+// BindXmlStructContext 解析XML数据，返回值为对象
+// Content-type:application/xml
+func BindXmlStructContext(c *gin.Context, rules []validator.Filter, obj interface{}, opts ...Option) (int32, error) {
+	return BindStruct(c, rules, obj, withOptions(opts, WithSource(SourceXML), WithContext())...)
+}
+
+// BindXmlStructRaw 解析XML数据，返回值为对象
+// 若校验失败，返回map格式的原始数据
+// Content-type:application/xml
+func BindXmlStructRaw(c *gin.Context, rules []validator.Filter, obj interface{}, opts ...Option) (int32, interface{}, error) {
+	return bindStruct(c, rules, obj, withOptions(opts, WithSource(SourceXML)))
+}
+
+// BindXmlStructRawContext 解析XML数据，返回值为对象
+// 若校验失败，返回map格式的原始数据
+// Content-type:application/xml
+func BindXmlStructRawContext(c *gin.Context, rules []validator.Filter, obj interface{}, opts ...Option) (int32, interface{}, error) {
+	return bindStruct(c, rules, obj, withOptions(opts, WithSource(SourceXML), WithContext()))
+}
+
+// BindYamlMap 解析YAML数据
+// Content-type:application/yaml
+func BindYamlMap(c *gin.Context, rules []validator.Filter, opts ...Option) (map[string]interface{}, int32, error) {
+	return Bind(c, rules, withOptions(opts, WithSource(SourceYAML))...)
+}
+
+// BindYamlMapContext 解析YAML数据
+// Content-type:application/yaml
+func BindYamlMapContext(c *gin.Context, rules []validator.Filter, opts ...Option) (map[string]interface{}, int32, error) {
+	return Bind(c, rules, withOptions(opts, WithSource(SourceYAML), WithContext())...)
+}
+
+// BindYamlStruct 解析YAML数据，返回值为对象
+// Content-type:application/yaml
+func BindYamlStruct(c *gin.Context, rules []validator.Filter, obj interface{}, opts ...Option) (int32, error) {
+	return BindStruct(c, rules, obj, withOptions(opts, WithSource(SourceYAML))...)
+}
+
+// BindYamlStructContext 解析YAML数据，返回值为对象
+// Content-type:application/yaml
+func BindYamlStructContext(c *gin.Context, rules []validator.Filter, obj interface{}, opts ...Option) (int32, error) {
+	return BindStruct(c, rules, obj, withOptions(opts, WithSource(SourceYAML), WithContext())...)
+}
+
+// BindYamlStructRaw 解析YAML数据，返回值为对象
+// 若校验失败，返回map格式的原始数据
+// Content-type:application/yaml
+func BindYamlStructRaw(c *gin.Context, rules []validator.Filter, obj interface{}, opts ...Option) (int32, interface{}, error) {
+	return bindStruct(c, rules, obj, withOptions(opts, WithSource(SourceYAML)))
+}
+
+// BindYamlStructRawContext 解析YAML数据，返回值为对象
+// 若校验失败，返回map格式的原始数据
+// Content-type:application/yaml
+func BindYamlStructRawContext(c *gin.Context, rules []validator.Filter, obj interface{}, opts ...Option) (int32, interface{}, error) {
+	return bindStruct(c, rules, obj, withOptions(opts, WithSource(SourceYAML), WithContext()))
+}
+
+// BindTomlMap 解析TOML数据
+// Content-type:application/toml
+func BindTomlMap(c *gin.Context, rules []validator.Filter, opts ...Option) (map[string]interface{}, int32, error) {
+	return Bind(c, rules, withOptions(opts, WithSource(SourceTOML))...)
+}
+
+// BindTomlMapContext 解析TOML数据
+// Content-type:application/toml
+func BindTomlMapContext(c *gin.Context, rules []validator.Filter, opts ...Option) (map[string]interface{}, int32, error) {
+	return Bind(c, rules, withOptions(opts, WithSource(SourceTOML), WithContext())...)
+}
+
+// BindTomlStruct 解析TOML数据，返回值为对象
+// Content-type:application/toml
+func BindTomlStruct(c *gin.Context, rules []validator.Filter, obj interface{}, opts ...Option) (int32, error) {
+	return BindStruct(c, rules, obj, withOptions(opts, WithSource(SourceTOML))...)
+}
+
+// BindTomlStructContext 解析TOML数据，返回值为对象
+// Content-type:application/toml
+func BindTomlStructContext(c *gin.Context, rules []validator.Filter, obj interface{}, opts ...Option) (int32, error) {
+	return BindStruct(c, rules, obj, withOptions(opts, WithSource(SourceTOML), WithContext())...)
+}
+
+// BindTomlStructRaw 解析TOML数据，返回值为对象
+// 若校验失败，返回map格式的原始数据
+// Content-type:application/toml
+func BindTomlStructRaw(c *gin.Context, rules []validator.Filter, obj interface{}, opts ...Option) (int32, interface{}, error) {
+	return bindStruct(c, rules, obj, withOptions(opts, WithSource(SourceTOML)))
+}
+
+// BindTomlStructRawContext 解析TOML数据，返回值为对象
+// 若校验失败，返回map格式的原始数据
+// Content-type:application/toml
+func BindTomlStructRawContext(c *gin.Context, rules []validator.Filter, obj interface{}, opts ...Option) (int32, interface{}, error) {
+	return bindStruct(c, rules, obj, withOptions(opts, WithSource(SourceTOML), WithContext()))
+}
+
+// BindMsgpackMap 解析MessagePack数据
+// Content-type:application/msgpack
+func BindMsgpackMap(c *gin.Context, rules []validator.Filter, opts ...Option) (map[string]interface{}, int32, error) {
+	return Bind(c, rules, withOptions(opts, WithSource(SourceMsgpack))...)
+}
+
+// BindMsgpackMapContext 解析MessagePack数据
+// Content-type:application/msgpack
+func BindMsgpackMapContext(c *gin.Context, rules []validator.Filter, opts ...Option) (map[string]interface{}, int32, error) {
+	return Bind(c, rules, withOptions(opts, WithSource(SourceMsgpack), WithContext())...)
+}
+
+// BindMsgpackStruct 解析MessagePack数据，返回值为对象
+// Content-type:application/msgpack
+func BindMsgpackStruct(c *gin.Context, rules []validator.Filter, obj interface{}, opts ...Option) (int32, error) {
+	return BindStruct(c, rules, obj, withOptions(opts, WithSource(SourceMsgpack))...)
+}
+
+// BindMsgpackStructContext 解析MessagePack数据，返回值为对象
+// Content-type:application/msgpack
+func BindMsgpackStructContext(c *gin.Context, rules []validator.Filter, obj interface{}, opts ...Option) (int32, error) {
+	return BindStruct(c, rules, obj, withOptions(opts, WithSource(SourceMsgpack), WithContext())...)
+}
+
+// BindMsgpackStructRaw 解析MessagePack数据，返回值为对象
+// 若校验失败，返回map格式的原始数据
+// Content-type:application/msgpack
+func BindMsgpackStructRaw(c *gin.Context, rules []validator.Filter, obj interface{}, opts ...Option) (int32, interface{}, error) {
+	return bindStruct(c, rules, obj, withOptions(opts, WithSource(SourceMsgpack)))
+}
+
+// BindMsgpackStructRawContext 解析MessagePack数据，返回值为对象
+// 若校验失败，返回map格式的原始数据
+// Content-type:application/msgpack
+func BindMsgpackStructRawContext(c *gin.Context, rules []validator.Filter, obj interface{}, opts ...Option) (int32, interface{}, error) {
+	return bindStruct(c, rules, obj, withOptions(opts, WithSource(SourceMsgpack), WithContext()))
+}
+
+// BindCborMap 解析CBOR数据
+// Content-type:application/cbor
+func BindCborMap(c *gin.Context, rules []validator.Filter, opts ...Option) (map[string]interface{}, int32, error) {
+	return Bind(c, rules, withOptions(opts, WithSource(SourceCBOR))...)
+}
+
+// BindCborMapContext 解析CBOR数据
+// Content-type:application/cbor
+func BindCborMapContext(c *gin.Context, rules []validator.Filter, opts ...Option) (map[string]interface{}, int32, error) {
+	return Bind(c, rules, withOptions(opts, WithSource(SourceCBOR), WithContext())...)
+}
+
+// BindCborStruct 解析CBOR数据，返回值为对象
+// Content-type:application/cbor
+func BindCborStruct(c *gin.Context, rules []validator.Filter, obj interface{}, opts ...Option) (int32, error) {
+	return BindStruct(c, rules, obj, withOptions(opts, WithSource(SourceCBOR))...)
+}
+
+// BindCborStructContext 解析CBOR数据，返回值为对象
+// Content-type:application/cbor
+func BindCborStructContext(c *gin.Context, rules []validator.Filter, obj interface{}, opts ...Option) (int32, error) {
+	return BindStruct(c, rules, obj, withOptions(opts, WithSource(SourceCBOR), WithContext())...)
+}
+
+// BindCborStructRaw 解析CBOR数据，返回值为对象
+// 若校验失败，返回map格式的原始数据
+// Content-type:application/cbor
+func BindCborStructRaw(c *gin.Context, rules []validator.Filter, obj interface{}, opts ...Option) (int32, interface{}, error) {
+	return bindStruct(c, rules, obj, withOptions(opts, WithSource(SourceCBOR)))
+}
+
+// BindCborStructRawContext 解析CBOR数据，返回值为对象
+// 若校验失败，返回map格式的原始数据
+// Content-type:application/cbor
+func BindCborStructRawContext(c *gin.Context, rules []validator.Filter, obj interface{}, opts ...Option) (int32, interface{}, error) {
+	return bindStruct(c, rules, obj, withOptions(opts, WithSource(SourceCBOR), WithContext()))
+}
+
+// ginContextKey *gin.Context在校验器context中的KEY类型
+type ginContextKey struct{}
+
+// GinContextKey 校验器可通过 ctx.Value(GinContextKey) 获取原始的 *gin.Context
+var GinContextKey = ginContextKey{}
+
+// GinContext 从校验器的context中获取原始的 *gin.Context
+func GinContext(ctx context.Context) (*gin.Context, bool) {
+	c, ok := ctx.Value(GinContextKey).(*gin.Context)
+	return c, ok
+}
+
+// keysContext 继承请求的context，并可通过字符串KEY读取gin.Context中的Keys
+type keysContext struct {
+	context.Context
+	c *gin.Context
+}
+
+func (kc keysContext) Value(key interface{}) interface{} {
+	if key == GinContextKey {
+		return kc.c
 	}
-	err = mapDecode(res, obj)
-	if err != nil {
-		return 0, res, err
+	if k, ok := key.(string); ok {
+		if v, exists := kc.c.Get(k); exists {
+			return v
+		}
 	}
-	return errCode, nil, nil
+	return kc.Context.Value(key)
 }
 
+// toContext 由请求的context派生校验器使用的context，随请求取消或超时
 func toContext(c *gin.Context) context.Context {
-	stdCtx := context.Background()
-	for k, v := range c.Keys {
-		stdCtx = context.WithValue(stdCtx, k, v)
-	}
-	return stdCtx
+	return keysContext{Context: c.Request.Context(), c: c}
 }
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"mime/multipart"
 	"net/http"
@@ -82,6 +83,16 @@ var multiRules = []V.Filter{
 	R.NewFilter("ids", []V.Validator{V.Required()}),
 }
 
+// newTestContext 创建测试用的gin.Context，contentType为空时不设置Content-Type
+func newTestContext(method, target, contentType string, body io.Reader) *gin.Context {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(method, target, body)
+	if contentType != "" {
+		c.Request.Header.Add("Content-Type", contentType)
+	}
+	return c
+}
+
 func init() {
 	router = gin.Default()
 
@@ -90,6 +101,8 @@ func init() {
 	router.POST("/form", formHandler)
 	router.POST("/multiform", multiFormHandler)
 	router.POST("/query", queryHandler)
+	router.POST("/path/:name", queryHandler)
+	router.POST("/bind", bindHandler)
 }
 
 func TestBindJSON(t *testing.T) {
@@ -340,7 +353,7 @@ func TestQueryJSON(t *testing.T) {
 // JSON传参
 func jsonHandler(c *gin.Context) {
 	var s SlideResp
-	code, err := BindJsonStruct(c, rules, &s)
+	code, err := BindJsonStruct(c, rules, &s, WithHeaders("x-data-id"))
 	if err != nil {
 		c.JSON(http.StatusOK, Resp{
 			Code: int(code),
@@ -1,10 +1,14 @@
 module github.com/rumis/ginvalidate
 
-go 1.16
+go 1.18
 
 require (
+	github.com/BurntSushi/toml v0.4.1
 	github.com/gin-gonic/gin v1.7.4
 	github.com/hetiansu5/urlquery v1.2.7
-	github.com/mitchellh/mapstructure v1.4.3
+	github.com/mitchellh/mapstructure v1.5.0
 	github.com/rumis/govalidate v0.2.6
+	github.com/ugorji/go/codec v1.1.7
+	google.golang.org/protobuf v1.27.1
+	gopkg.in/yaml.v2 v2.4.0
 )
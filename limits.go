@@ -0,0 +1,92 @@
+package ginvalidate
+
+import (
+	"bytes"
+	"io"
+	"mime"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// defaultMultipartMemory 解析multipart时默认使用的内存，超出部分写入临时文件
+const defaultMultipartMemory = 10240
+
+// limitedBody 限制请求体大小与multipart分段数量
+type limitedBody struct {
+	io.ReadCloser
+	remaining int64 // 剩余可读取的字节数，小于0时不限制
+	maxBytes  int64
+	maxParts  int
+	parts     int    // 已读取到的分隔符数量
+	delim     []byte // multipart分隔符
+	tail      []byte // 上次读取的末尾，用于匹配跨越两次读取的分隔符
+	err       error  // 超出限制时的错误
+}
+
+// limitBody 按配置限制请求体，未配置限制时返回nil
+func limitBody(c *gin.Context, o *options) *limitedBody {
+	if c.Request.Body == nil || (o.maxBodyBytes <= 0 && o.maxParts <= 0) {
+		return nil
+	}
+	lb := &limitedBody{
+		ReadCloser: c.Request.Body,
+		remaining:  -1,
+		maxBytes:   o.maxBodyBytes,
+	}
+	if o.maxBodyBytes > 0 {
+		lb.remaining = o.maxBodyBytes
+	}
+	if o.maxParts > 0 {
+		mt, params, err := mime.ParseMediaType(c.GetHeader("Content-Type"))
+		if err == nil && mt == binding.MIMEMultipartPOSTForm && params["boundary"] != "" {
+			lb.maxParts = o.maxParts
+			lb.delim = []byte("--" + params["boundary"])
+		}
+	}
+	c.Request.Body = lb
+	return lb
+}
+
+func (lb *limitedBody) Read(p []byte) (int, error) {
+	if lb.err != nil {
+		return 0, lb.err
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+	if lb.remaining >= 0 && int64(len(p)) > lb.remaining+1 {
+		p = p[:lb.remaining+1]
+	}
+	n, err := lb.ReadCloser.Read(p)
+	if lb.remaining >= 0 {
+		if int64(n) > lb.remaining {
+			n = int(lb.remaining)
+			lb.remaining = 0
+			lb.err = &PayloadTooLargeError{Limit: lb.maxBytes, Kind: "body"}
+			return n, lb.err
+		}
+		lb.remaining -= int64(n)
+	}
+	if lb.maxParts > 0 && n > 0 {
+		lb.countParts(p[:n])
+		// 最后一个分隔符为结束标记，分段数量为分隔符数量减一
+		if lb.parts-1 > lb.maxParts {
+			lb.err = &PayloadTooLargeError{Limit: int64(lb.maxParts), Kind: "parts"}
+			return n, lb.err
+		}
+	}
+	return n, err
+}
+
+// countParts 统计multipart分隔符的数量
+func (lb *limitedBody) countParts(p []byte) {
+	buf := make([]byte, 0, len(lb.tail)+len(p))
+	buf = append(append(buf, lb.tail...), p...)
+	lb.parts += bytes.Count(buf, lb.delim)
+	keep := len(lb.delim) - 1
+	if keep > len(buf) {
+		keep = len(buf)
+	}
+	lb.tail = append(lb.tail[:0], buf[len(buf)-keep:]...)
+}
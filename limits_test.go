@@ -0,0 +1,45 @@
+package ginvalidate
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestPayloadLimits(t *testing.T) {
+
+	body := `{"name":"` + strings.Repeat("a", 100) + `"}`
+
+	c := newTestContext("POST", "/json", "application/json", strings.NewReader(body))
+
+	_, _, err := BindJsonMap(c, multiRules[:1], WithMaxBodyBytes(64))
+	var pErr *PayloadTooLargeError
+	if !errors.As(err, &pErr) || pErr.Kind != "body" {
+		t.Fatalf("body limit error: %v", err)
+	}
+	if ErrorStatus(err) != http.StatusRequestEntityTooLarge {
+		t.Errorf("body limit status error: %d", ErrorStatus(err))
+	}
+
+	c = newTestContext("POST", "/json", "application/json", strings.NewReader(body))
+	if _, _, err := BindJsonMap(c, multiRules[:1], WithMaxBodyBytes(int64(len(body)))); err != nil {
+		t.Errorf("body within limit error: %v", err)
+	}
+
+	// 4个分段
+	c = newUploadContext()
+	_, _, err = BindFormMap(c, multiRules[:1], WithMaxParts(2))
+	if !errors.As(err, &pErr) || pErr.Kind != "parts" {
+		t.Fatalf("parts limit error: %v", err)
+	}
+
+	c = newUploadContext()
+	res, _, err := BindFormMap(c, multiRules[:1], WithMaxParts(4), WithMultipartMemory(1<<20))
+	if err != nil {
+		t.Fatalf("parts within limit error: %v", err)
+	}
+	if _, ok := res["avatar"]; !ok {
+		t.Error("multipart file not bound")
+	}
+}
@@ -0,0 +1,69 @@
+package ginvalidate
+
+import (
+	"reflect"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rumis/govalidate/validator"
+)
+
+const (
+	// ParamsKey 校验通过的参数在gin.Context中的KEY
+	ParamsKey = "ginvalidate.params"
+	// ObjectKey 校验通过并转换后的对象在gin.Context中的KEY
+	ObjectKey = "ginvalidate.object"
+	// BodyKey WithBodyReplay 缓存的原始请求体在gin.Context中的KEY，值为 []byte
+	BodyKey = "ginvalidate.body"
+)
+
+// Middleware 在handler之前执行参数校验
+// 校验通过时，参数保存在gin.Context中，通过 GetParams、GetObject 获取
+// 校验失败时，中止请求并使用 WithRenderer 设置的Renderer输出错误
+func Middleware(rules []validator.Filter, opts ...Option) gin.HandlerFunc {
+	o := newOptions(opts)
+	return func(c *gin.Context) {
+		res, errCode, err := Bind(c, rules, opts...)
+		if err != nil {
+			c.Abort()
+			o.renderer.Render(c, errCode, err)
+			return
+		}
+		c.Set(ParamsKey, res)
+		if o.objectType != nil {
+			obj := reflect.New(o.objectType)
+			if err := mapDecode(res, obj.Interface(), o.decoder); err != nil {
+				c.Abort()
+				o.renderer.Render(c, 0, &DecodeError{Err: err})
+				return
+			}
+			c.Set(ObjectKey, obj.Interface())
+		}
+		c.Next()
+	}
+}
+
+// GetParams 获取中间件校验通过的参数
+func GetParams(c *gin.Context) (map[string]interface{}, bool) {
+	v, ok := c.Get(ParamsKey)
+	if !ok {
+		return nil, false
+	}
+	res, ok := v.(map[string]interface{})
+	return res, ok
+}
+
+// GetObject 获取中间件转换后的对象
+// out 须为指向 WithObject 注册类型的指针
+func GetObject(c *gin.Context, out interface{}) bool {
+	v, ok := c.Get(ObjectKey)
+	if !ok {
+		return false
+	}
+	src := reflect.ValueOf(v)
+	dst := reflect.ValueOf(out)
+	if dst.Kind() != reflect.Ptr || dst.IsNil() || dst.Type() != src.Type() {
+		return false
+	}
+	dst.Elem().Set(src.Elem())
+	return true
+}
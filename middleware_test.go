@@ -0,0 +1,60 @@
+package ginvalidate
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestMiddleware(t *testing.T) {
+
+	r := gin.New()
+	r.POST("/middleware", Middleware(rules, WithObject(SlideResp{})), func(c *gin.Context) {
+		var s SlideResp
+		if !GetObject(c, &s) {
+			c.String(http.StatusInternalServerError, "object not found")
+			return
+		}
+		c.String(http.StatusOK, s.Name)
+	})
+
+	s1 := map[string]interface{}{
+		"name":     "课件",
+		"ids":      "1,2,3",
+		"grade":    2,
+		"subjects": []int{3, 4, 12},
+		"ctime":    time.Now().Format("2006-01-02 15:04:05"),
+		"email":    "liumurong1@tal.com",
+		"phone":    "15810562936",
+		"stat":     3,
+		"school":   1,
+		"cname":    []string{"a", "b", "c"},
+	}
+	s1Byte, _ := json.Marshal(s1)
+
+	req := httptest.NewRequest("POST", "/middleware", bytes.NewReader(s1Byte))
+	req.Header.Add("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK || w.Body.String() != "课件" {
+		t.Errorf("middleware bind error: %d %s", w.Code, w.Body.String())
+	}
+
+	delete(s1, "name")
+	s1Byte, _ = json.Marshal(s1)
+
+	req = httptest.NewRequest("POST", "/middleware", bytes.NewReader(s1Byte))
+	req.Header.Add("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("middleware abort error: %d", w.Code)
+	}
+}
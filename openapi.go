@@ -0,0 +1,411 @@
+package ginvalidate
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/rumis/govalidate/validator"
+)
+
+// OpenAPIVersion 生成文档使用的OpenAPI版本
+const OpenAPIVersion = "3.0.3"
+
+// RuleSet 一组校验规则及其参数来源
+// 只有 Filters 中带校验器名称的规则生成类型、枚举、范围、默认值，RegisterStruct 根据 validate tag 生成 Filters
+// Rules 中的govalidate规则无法获取校验器定义，文档中均为选填的字符串参数
+// 包含上传文件校验器的规则为 multipart/form-data 请求体的文件字段
+type RuleSet struct {
+	Source  Source
+	Rules   []validator.Filter
+	Filters []Filter
+}
+
+// route 注册的路由
+type route struct {
+	Method string
+	Path   string
+	Sets   []RuleSet
+}
+
+// Registry 路由校验规则注册表，用于生成OpenAPI文档
+type Registry struct {
+	mu     sync.RWMutex
+	routes []route
+}
+
+// DefaultRegistry 默认注册表
+var DefaultRegistry = NewRegistry()
+
+// NewRegistry 创建注册表
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register 注册路由的校验规则
+func (r *Registry) Register(method, path string, sets ...RuleSet) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.routes = append(r.routes, route{Method: strings.ToUpper(method), Path: path, Sets: sets})
+}
+
+// RegisterStruct 根据对象的 validate tag 注册路由的校验规则
+func (r *Registry) RegisterStruct(method, path string, src Source, obj interface{}) error {
+	fs, err := filtersFor(obj)
+	if err != nil {
+		return err
+	}
+	r.Register(method, path, RuleSet{Source: src, Filters: fs})
+	return nil
+}
+
+// Register 向默认注册表注册路由的校验规则
+func Register(method, path string, sets ...RuleSet) {
+	DefaultRegistry.Register(method, path, sets...)
+}
+
+// RegisterStruct 根据对象的 validate tag 向默认注册表注册路由的校验规则
+func RegisterStruct(method, path string, src Source, obj interface{}) error {
+	return DefaultRegistry.RegisterStruct(method, path, src, obj)
+}
+
+// Info OpenAPI文档的info字段
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// Document OpenAPI文档
+type Document struct {
+	OpenAPI string              `json:"openapi"`
+	Info    Info                `json:"info"`
+	Paths   map[string]PathItem `json:"paths"`
+}
+
+// PathItem 路径下各请求方法的定义，KEY为小写的请求方法
+type PathItem map[string]*Operation
+
+// Operation 单个接口的定义
+type Operation struct {
+	Parameters  []Parameter         `json:"parameters,omitempty"`
+	RequestBody *RequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]Response `json:"responses"`
+}
+
+// Parameter 路径、查询、header参数
+type Parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required,omitempty"`
+	Schema   *Schema `json:"schema"`
+}
+
+// RequestBody 请求体
+type RequestBody struct {
+	Required bool                 `json:"required,omitempty"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+// MediaType 请求体格式
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Response 响应
+type Response struct {
+	Description string `json:"description"`
+}
+
+// Schema 参数的JSON Schema
+type Schema struct {
+	Type       string             `json:"type,omitempty"`
+	Format     string             `json:"format,omitempty"`
+	Pattern    string             `json:"pattern,omitempty"`
+	Enum       []interface{}      `json:"enum,omitempty"`
+	Minimum    *float64           `json:"minimum,omitempty"`
+	Maximum    *float64           `json:"maximum,omitempty"`
+	Default    interface{}        `json:"default,omitempty"`
+	Items      *Schema            `json:"items,omitempty"`
+	Properties map[string]*Schema `json:"properties,omitempty"`
+	Required   []string           `json:"required,omitempty"`
+}
+
+// OpenAPI 生成OpenAPI文档
+func (r *Registry) OpenAPI(info Info) *Document {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	doc := &Document{
+		OpenAPI: OpenAPIVersion,
+		Info:    info,
+		Paths:   make(map[string]PathItem),
+	}
+	for _, rt := range r.routes {
+		path, pathParams := openAPIPath(rt.Path)
+		item, ok := doc.Paths[path]
+		if !ok {
+			item = make(PathItem)
+			doc.Paths[path] = item
+		}
+		item[strings.ToLower(rt.Method)] = rt.operation(pathParams)
+	}
+	return doc
+}
+
+// Handler 输出OpenAPI文档的gin handler
+func (r *Registry) Handler(info Info) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, r.OpenAPI(info))
+	}
+}
+
+// ServeOpenAPI 在 /openapi.json 提供默认注册表生成的文档
+func ServeOpenAPI(router gin.IRoutes, info Info) {
+	router.GET("/openapi.json", DefaultRegistry.Handler(info))
+}
+
+// operation 生成接口定义
+func (rt route) operation(pathParams []string) *Operation {
+	op := &Operation{
+		Responses: map[string]Response{"200": {Description: "OK"}},
+	}
+	inPath := make(map[string]bool, len(pathParams))
+	for _, p := range pathParams {
+		inPath[p] = true
+	}
+	documented := make(map[string]bool)
+	for _, set := range rt.Sets {
+		in := parameterIn(set.Source, rt.Method)
+		var body, files *Schema
+		for _, fr := range set.describe() {
+			s, required := fieldSchema(fr)
+			switch {
+			case inPath[fr.Key]:
+				op.Parameters = append(op.Parameters, Parameter{Name: fr.Key, In: "path", Required: true, Schema: s})
+				documented[fr.Key] = true
+			case isFileSchema(s):
+				if files == nil {
+					files = &Schema{Type: "object", Properties: make(map[string]*Schema)}
+				}
+				files.Properties[fr.Key] = s
+				if required {
+					files.Required = append(files.Required, fr.Key)
+				}
+			case in != "":
+				op.Parameters = append(op.Parameters, Parameter{Name: fr.Key, In: in, Required: required, Schema: s})
+			default:
+				if body == nil {
+					body = &Schema{Type: "object", Properties: make(map[string]*Schema)}
+				}
+				body.Properties[fr.Key] = s
+				if required {
+					body.Required = append(body.Required, fr.Key)
+				}
+			}
+		}
+		if body == nil && files == nil {
+			continue
+		}
+		if op.RequestBody == nil {
+			op.RequestBody = &RequestBody{Content: make(map[string]MediaType)}
+		}
+		if body != nil {
+			op.RequestBody.Required = op.RequestBody.Required || len(body.Required) > 0
+			for _, ct := range bodyContentTypes(set.Source) {
+				op.RequestBody.Content[ct] = MediaType{Schema: body}
+			}
+		}
+		if files != nil {
+			form := multipartSchema(body, files)
+			op.RequestBody.Required = op.RequestBody.Required || len(form.Required) > 0
+			op.RequestBody.Content[binding.MIMEMultipartPOSTForm] = MediaType{Schema: form}
+		}
+	}
+	// 未在规则中声明的路径参数
+	for _, p := range pathParams {
+		if !documented[p] {
+			op.Parameters = append(op.Parameters, Parameter{Name: p, In: "path", Required: true, Schema: &Schema{Type: "string"}})
+		}
+	}
+	return op
+}
+
+// describe 取 Filters 的校验器名称与参数，Rules 中的规则没有校验器定义
+func (set RuleSet) describe() []fieldRules {
+	fields := make([]fieldRules, 0, len(set.Rules)+len(set.Filters))
+	for _, f := range set.Rules {
+		fields = append(fields, fieldRules{Key: f.Key})
+	}
+	for _, f := range set.Filters {
+		fr := fieldRules{Key: f.Key}
+		for _, r := range f.Rules {
+			fr.Rules = append(fr.Rules, tagRule{Name: strings.ToLower(r.Name), Args: r.Args})
+		}
+		fields = append(fields, fr)
+	}
+	return fields
+}
+
+// fieldSchema 根据校验器生成字段的Schema
+func fieldSchema(fr fieldRules) (*Schema, bool) {
+	s := &Schema{}
+	required, file, multiple := false, false, false
+	for _, r := range fr.Rules {
+		switch r.Name {
+		case "required":
+			required = true
+		case "filerequired":
+			required, file = true, true
+		case "filemaxsize", "fileext", "filemime":
+			file = true
+		case "filecount":
+			file = true
+			if len(r.Args) == 2 {
+				min, _ := strconv.Atoi(r.Args[0])
+				max, _ := strconv.Atoi(r.Args[1])
+				required = required || min > 0
+				multiple = max > 1
+			}
+		case "optional":
+			if len(r.Args) > 0 {
+				s.Default = schemaValue(r.Args[0])
+			}
+		case "int":
+			s.Type = "integer"
+		case "between":
+			if len(r.Args) == 2 {
+				s.Minimum = schemaFloat(r.Args[0])
+				s.Maximum = schemaFloat(r.Args[1])
+			}
+		case "enumint":
+			s.Type = "integer"
+			for _, a := range r.Args {
+				s.Enum = append(s.Enum, schemaValue(a))
+			}
+		case "email":
+			s.Type, s.Format = "string", "email"
+		case "phone":
+			s.Type = "string"
+		case "datetime":
+			s.Type, s.Pattern = "string", `^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}$`
+		case "dotint":
+			s.Type, s.Pattern = "string", `^\d+(,\d+)*$`
+		case "intslice":
+			s.Type, s.Items = "array", &Schema{Type: "integer"}
+			if len(r.Args) == 2 && strings.HasPrefix(r.Args[0], "between=") {
+				s.Items.Minimum = schemaFloat(strings.TrimPrefix(r.Args[0], "between="))
+				s.Items.Maximum = schemaFloat(r.Args[1])
+			}
+		case "stringslice":
+			s.Type, s.Items = "array", &Schema{Type: "string"}
+		}
+	}
+	if file {
+		// 上传文件，FileCount 允许多个文件时为数组
+		s = &Schema{Type: "string", Format: "binary"}
+		if multiple {
+			s = &Schema{Type: "array", Items: s}
+		}
+	}
+	if s.Type == "" {
+		s.Type = "string"
+	}
+	return s, required
+}
+
+// isFileSchema 判断Schema是否为上传文件字段
+func isFileSchema(s *Schema) bool {
+	return s.Format == "binary" || s.Items != nil && s.Items.Format == "binary"
+}
+
+// multipartSchema 在请求体参数的基础上增加上传文件字段
+func multipartSchema(body *Schema, files *Schema) *Schema {
+	form := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	for _, part := range []*Schema{body, files} {
+		if part == nil {
+			continue
+		}
+		for k, v := range part.Properties {
+			form.Properties[k] = v
+		}
+		form.Required = append(form.Required, part.Required...)
+	}
+	return form
+}
+
+// parameterIn 参数来源对应的OpenAPI参数位置，请求体参数返回空
+func parameterIn(src Source, method string) string {
+	switch src {
+	case SourceQuery:
+		return "query"
+	case SourceHeader:
+		return "header"
+	case SourceAuto:
+		switch method {
+		case http.MethodGet, http.MethodHead, http.MethodDelete, http.MethodOptions:
+			return "query"
+		}
+	}
+	return ""
+}
+
+// bodyContentTypes 参数来源对应的请求体Content-Type
+func bodyContentTypes(src Source) []string {
+	switch src {
+	case SourceJSON:
+		return []string{binding.MIMEJSON}
+	case SourceForm:
+		return []string{binding.MIMEPOSTForm, binding.MIMEMultipartPOSTForm}
+	case SourceXML:
+		return []string{binding.MIMEXML, binding.MIMEXML2}
+	case SourceYAML:
+		return []string{MIMEYAML, binding.MIMEYAML}
+	case SourceTOML:
+		return []string{MIMETOML}
+	case SourceMsgpack:
+		return []string{binding.MIMEMSGPACK2, binding.MIMEMSGPACK}
+	case SourceCBOR:
+		return []string{MIMECBOR}
+	case SourceProtobuf:
+		return []string{binding.MIMEPROTOBUF}
+	}
+	return []string{binding.MIMEJSON, binding.MIMEPOSTForm, binding.MIMEMultipartPOSTForm}
+}
+
+// openAPIPath 将gin路由转为OpenAPI路径，如 /users/:id 转为 /users/{id}
+func openAPIPath(path string) (string, []string) {
+	var params []string
+	segs := strings.Split(path, "/")
+	for i, seg := range segs {
+		if strings.HasPrefix(seg, ":") || strings.HasPrefix(seg, "*") {
+			params = append(params, seg[1:])
+			segs[i] = "{" + seg[1:] + "}"
+		}
+	}
+	sort.Strings(params)
+	return strings.Join(segs, "/"), params
+}
+
+// schemaValue 将tag参数转为数字或字符串
+func schemaValue(s string) interface{} {
+	if i, err := strconv.Atoi(s); err == nil {
+		return i
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return f
+	}
+	return s
+}
+
+// schemaFloat 将tag参数转为数字
+func schemaFloat(s string) *float64 {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil
+	}
+	return &f
+}
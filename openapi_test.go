@@ -0,0 +1,122 @@
+package ginvalidate
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestOpenAPI(t *testing.T) {
+
+	slideFilters := []Filter{
+		{Key: "name", Rules: []Rule{Required()}},
+		{Key: "grade", Rules: []Rule{Required(), Int(), Between(1, 100)}},
+		{Key: "stat", Rules: []Rule{Required(), EnumInt(1, 2, 3, 4, 5)}},
+		{Key: "page", Rules: []Rule{Optional(101), Int()}},
+	}
+	reg := NewRegistry()
+	reg.Register("GET", "/slides/:id", RuleSet{Source: SourceQuery, Filters: slideFilters})
+	reg.Register("DELETE", "/slides/:id", RuleSet{Source: SourceQuery, Rules: rules})
+	reg.Register("POST", "/slides", RuleSet{
+		Source:  SourceHeader,
+		Filters: []Filter{{Key: "x-data-id", Rules: []Rule{Required(), Int()}}},
+	})
+	reg.Register("POST", "/upload", RuleSet{
+		Source: SourceForm,
+		Filters: []Filter{
+			{Key: "name", Rules: []Rule{Required()}},
+			{Key: "avatar", Rules: []Rule{FileRequired(), FileMaxSize(1 << 20)}},
+			{Key: "photos", Rules: []Rule{FileCount(0, 5)}},
+		},
+	})
+	if err := reg.RegisterStruct("PUT", "/slides/:id", SourceJSON, &TagReq{}); err != nil {
+		t.Fatal(err)
+	}
+
+	r := gin.New()
+	r.GET("/openapi.json", reg.Handler(Info{Title: "slides", Version: "1.0"}))
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/openapi.json", nil))
+
+	var doc Document
+	if err := json.Unmarshal(w.Body.Bytes(), &doc); err != nil {
+		t.Fatal(err)
+	}
+	item, ok := doc.Paths["/slides/{id}"]
+	if !ok {
+		t.Fatal("path /slides/{id} not exist")
+	}
+
+	get := item["get"]
+	if get == nil || len(get.Parameters) != len(slideFilters)+1 {
+		t.Fatalf("get parameters error: %v", get)
+	}
+	if p := get.Parameters[len(get.Parameters)-1]; p.Name != "id" || p.In != "path" {
+		t.Errorf("path parameter error: %v", p)
+	}
+
+	put := item["put"]
+	if put == nil || put.RequestBody == nil {
+		t.Fatal("put request body not exist")
+	}
+	body := put.RequestBody.Content["application/json"].Schema
+	if grade := body.Properties["grade"]; grade == nil || grade.Type != "integer" || *grade.Maximum != 100 {
+		t.Errorf("grade schema error: %v", grade)
+	}
+	if stat := body.Properties["stat"]; stat == nil || len(stat.Enum) != 5 {
+		t.Errorf("stat schema error: %v", stat)
+	}
+	if page := body.Properties["page"]; page == nil || page.Default != float64(101) {
+		t.Errorf("page schema error: %v", page)
+	}
+
+	post := doc.Paths["/slides"]["post"]
+	if post == nil || len(post.Parameters) != 1 || post.Parameters[0].In != "header" || !post.Parameters[0].Required {
+		t.Errorf("header parameter error: %v", post)
+	}
+
+	upload := doc.Paths["/upload"]["post"]
+	if upload == nil || upload.RequestBody == nil || !upload.RequestBody.Required {
+		t.Fatalf("upload request body error: %v", upload)
+	}
+	form := upload.RequestBody.Content["multipart/form-data"].Schema
+	if avatar := form.Properties["avatar"]; avatar == nil || avatar.Format != "binary" {
+		t.Errorf("avatar schema error: %v", avatar)
+	}
+	if photos := form.Properties["photos"]; photos == nil || photos.Type != "array" || photos.Items.Format != "binary" {
+		t.Errorf("photos schema error: %v", photos)
+	}
+	if form.Properties["name"] == nil || len(form.Required) != 2 {
+		t.Errorf("multipart schema error: %+v", form)
+	}
+	if urlencoded := upload.RequestBody.Content["application/x-www-form-urlencoded"].Schema; urlencoded.Properties["avatar"] != nil {
+		t.Error("file documented in urlencoded body")
+	}
+
+	// Register 的 Filters 同样包含校验器参数
+	params := make(map[string]*Schema)
+	for _, p := range get.Parameters {
+		params[p.Name] = p.Schema
+	}
+	if grade := params["grade"]; grade == nil || grade.Type != "integer" || *grade.Minimum != 1 || *grade.Maximum != 100 {
+		t.Errorf("register grade schema error: %v", grade)
+	}
+	if stat := params["stat"]; stat == nil || len(stat.Enum) != 5 || stat.Enum[4] != float64(5) {
+		t.Errorf("register stat schema error: %v", stat)
+	}
+	if page := params["page"]; page == nil || page.Default != float64(101) {
+		t.Errorf("register page schema error: %v", page)
+	}
+
+	// govalidate的规则没有校验器定义，均为选填的字符串参数
+	del := item["delete"]
+	if del == nil || len(del.Parameters) != len(rules)+1 {
+		t.Fatalf("delete parameters error: %v", del)
+	}
+	if p := del.Parameters[0]; p.Name != "name" || p.Required || p.Schema.Type != "string" {
+		t.Errorf("untyped parameter error: %v", p)
+	}
+}
@@ -0,0 +1,299 @@
+package ginvalidate
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Precedence 路径参数与其他来源参数同名时的优先级
+type Precedence int
+
+const (
+	// PathParamsFirst 路径参数覆盖body/query/form中的同名参数
+	PathParamsFirst Precedence = iota
+	// PathParamsLast 同名时保留body/query/form中的参数
+	PathParamsLast
+)
+
+// Option 绑定选项
+type Option func(*options)
+
+// options 绑定配置
+type options struct {
+	source          Source
+	context         bool
+	pathPrecedence  Precedence
+	collectAll      bool
+	objectType      reflect.Type
+	renderer        Renderer
+	headers         map[string]bool
+	headerPrefix    string
+	legacyHeaders   bool
+	maxBodyBytes    int64
+	multipartMemory int64
+	maxParts        int
+	binaryMode      BinaryMode
+	batchPolicy     BatchPolicy
+	maxLineBytes    int
+	nestedKeys      bool
+	replayBytes     int64
+	decoder         DecoderOptions
+	strict          bool
+	filters         []Filter
+	maxExpansion    int
+}
+
+// defaultOptions 全局默认配置
+var defaultOptions = options{
+	pathPrecedence:  PathParamsFirst,
+	renderer:        EnvelopeRenderer{},
+	multipartMemory: defaultMultipartMemory,
+	maxLineBytes:    defaultMaxLineBytes,
+	decoder:         DecoderOptions{TimeLayouts: defaultTimeLayouts},
+	maxExpansion:    defaultMaxPathExpansion,
+}
+
+// SetDefaultOptions 设置全局默认选项
+// 非并发安全，应在程序初始化阶段调用
+func SetDefaultOptions(opts ...Option) {
+	for _, opt := range opts {
+		opt(&defaultOptions)
+	}
+}
+
+// newOptions 在全局默认配置的基础上应用单次调用的选项
+func newOptions(opts []Option) *options {
+	o := defaultOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &o
+}
+
+// withOptions 在调用方选项之后追加选项，不修改调用方的切片
+func withOptions(opts []Option, extra ...Option) []Option {
+	res := make([]Option, 0, len(opts)+len(extra))
+	res = append(res, opts...)
+	return append(res, extra...)
+}
+
+// WithSource 指定参数来源，不再根据Content-Type自动选择
+func WithSource(s Source) Option {
+	return func(o *options) {
+		o.source = s
+	}
+}
+
+// WithContext 校验时传入由请求context派生的context，校验器可读取gin.Context中的Keys
+func WithContext() Option {
+	return func(o *options) {
+		o.context = true
+	}
+}
+
+// WithPathPrecedence 设置路径参数的优先级
+func WithPathPrecedence(p Precedence) Option {
+	return func(o *options) {
+		o.pathPrecedence = p
+	}
+}
+
+// WithCollectAll 执行全部校验规则，返回所有校验失败的参数
+// 校验失败时返回的错误类型为 ValidationErrors
+func WithCollectAll() Option {
+	return func(o *options) {
+		o.collectAll = true
+	}
+}
+
+// WithObject 注册中间件转换的对象类型，obj 为该类型的值或指针
+func WithObject(obj interface{}) Option {
+	return func(o *options) {
+		t := reflect.TypeOf(obj)
+		for t != nil && t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		o.objectType = t
+	}
+}
+
+// WithRenderer 设置校验失败时输出错误的Renderer
+// 通过 SetDefaultOptions 设置时全局生效
+func WithRenderer(r Renderer) Option {
+	return func(o *options) {
+		o.renderer = r
+	}
+}
+
+// WithErrorHandler 以函数形式设置校验失败时的处理
+func WithErrorHandler(h ErrorHandler) Option {
+	return WithRenderer(h)
+}
+
+// WithHeaders 允许合并到参数中的header，KEY不区分大小写
+// 默认不合并任何header
+func WithHeaders(keys ...string) Option {
+	return func(o *options) {
+		headers := make(map[string]bool, len(o.headers)+len(keys))
+		for k := range o.headers {
+			headers[k] = true
+		}
+		for _, k := range keys {
+			headers[strings.ToLower(k)] = true
+		}
+		o.headers = headers
+	}
+}
+
+// WithHeaderPrefix 合并header时为KEY增加前缀，如 "header." 时 X-Data-Id 的KEY为 header.x-data-id
+// 仅作用于 WithHeaders 允许的header，不会扩大合并范围
+func WithHeaderPrefix(prefix string) Option {
+	return func(o *options) {
+		o.headerPrefix = prefix
+	}
+}
+
+// WithLegacyHeaders 以小写KEY合并全部header，兼容旧版本的行为
+func WithLegacyHeaders() Option {
+	return func(o *options) {
+		o.legacyHeaders = true
+	}
+}
+
+// headerKey header在参数中的KEY，不合并时返回false
+func (o *options) headerKey(k string) (string, bool) {
+	k = strings.ToLower(k)
+	switch {
+	case o.legacyHeaders:
+		return k, true
+	case o.headers[k]:
+		return o.headerPrefix + k, true
+	}
+	return "", false
+}
+
+// WithMaxBodyBytes 请求体的最大字节数，超出时返回 *PayloadTooLargeError
+func WithMaxBodyBytes(n int64) Option {
+	return func(o *options) {
+		o.maxBodyBytes = n
+	}
+}
+
+// WithMultipartMemory 解析multipart时使用的内存，超出部分写入临时文件
+func WithMultipartMemory(n int64) Option {
+	return func(o *options) {
+		o.multipartMemory = n
+	}
+}
+
+// WithMaxParts multipart的最大分段数量，超出时返回 *PayloadTooLargeError
+func WithMaxParts(n int) Option {
+	return func(o *options) {
+		o.maxParts = n
+	}
+}
+
+// WithBinaryMode 设置msgpack与CBOR中二进制类型的值的表示方式，默认保持为 []byte
+func WithBinaryMode(m BinaryMode) Option {
+	return func(o *options) {
+		o.binaryMode = m
+	}
+}
+
+// WithBatchPolicy 设置批量参数中部分元素校验失败时的处理方式，默认为 BatchRejectAll
+func WithBatchPolicy(p BatchPolicy) Option {
+	return func(o *options) {
+		o.batchPolicy = p
+	}
+}
+
+// WithMaxLineBytes NDJSON单行的最大字节数，超出时中止读取并返回 *ParseError
+func WithMaxLineBytes(n int) Option {
+	return func(o *options) {
+		o.maxLineBytes = n
+	}
+}
+
+// WithNestedKeys 按中括号解析查询参数与form参数的层级
+// 如 filter[owner][id]=5 解析为 {"filter":{"owner":{"id":"5"}}}，items[0][name]=a 解析为 {"items":[{"name":"a"}]}
+func WithNestedKeys() Option {
+	return func(o *options) {
+		o.nestedKeys = true
+	}
+}
+
+// WithMaxPathExpansion 校验规则KEY中通配符展开的路径总数上限，超出时返回 *PayloadTooLargeError
+// 小于等于0时不限制
+func WithMaxPathExpansion(n int) Option {
+	return func(o *options) {
+		o.maxExpansion = n
+	}
+}
+
+// WithBodyReplay 缓存不超过n字节的请求体，解析后将 c.Request.Body 恢复为可重新读取的内容
+// 缓存的请求体保存在gin.Context中，KEY为 BodyKey，通过 GetBody 获取；超出n字节时不缓存
+func WithBodyReplay(n int64) Option {
+	return func(o *options) {
+		o.replayBytes = n
+	}
+}
+
+// WithDecoderOptions 设置校验结果转为对象时的转换配置，对全部 *Struct 方法与中间件生效
+// 通过 SetDefaultOptions 设置时全局生效
+func WithDecoderOptions(d DecoderOptions) Option {
+	return func(o *options) {
+		o.decoder = d
+	}
+}
+
+// WithTimeLayouts 设置字符串转为time.Time时依次尝试的格式，替换默认格式
+func WithTimeLayouts(layouts ...string) Option {
+	return func(o *options) {
+		o.decoder.TimeLayouts = layouts
+	}
+}
+
+// WithTimeLocation 设置不含时区的时间字符串使用的时区
+func WithTimeLocation(loc *time.Location) Option {
+	return func(o *options) {
+		o.decoder.Location = loc
+	}
+}
+
+// WithEpoch 设置数字转为time.Time时的单位
+func WithEpoch(unit EpochUnit) Option {
+	return func(o *options) {
+		o.decoder.Epoch = unit
+	}
+}
+
+// WithFilters 追加带校验器名称的校验规则，在传入的规则之后执行
+// 校验失败时 ValidationError.Rule 为校验器名称
+func WithFilters(fs ...Filter) Option {
+	return func(o *options) {
+		o.filters = append(append([]Filter{}, o.filters...), fs...)
+	}
+}
+
+// WithStrict body与query中存在校验规则未声明的参数时校验失败，错误码为 ErrCodeUnknownField
+// 嵌套的map与切片逐层检查
+func WithStrict() Option {
+	return func(o *options) {
+		o.strict = true
+	}
+}
+
+// WithErrorUnused 参数中存在对象没有的字段时转换失败
+func WithErrorUnused() Option {
+	return func(o *options) {
+		o.decoder.ErrorUnused = true
+	}
+}
+
+// WithErrorUnset 对象中存在参数没有的字段时转换失败，用于发现未校验的字段
+func WithErrorUnset() Option {
+	return func(o *options) {
+		o.decoder.ErrorUnset = true
+	}
+}
@@ -0,0 +1,36 @@
+package ginvalidate
+
+import (
+	"testing"
+)
+
+// 测试header参数的合并范围
+func TestHeaderBinding(t *testing.T) {
+
+	cases := []struct {
+		opts []Option
+		keys map[string]bool
+	}{
+		{nil, map[string]bool{"x-data-id": false, "authorization": false}},
+		{[]Option{WithHeaders("X-Data-Id")}, map[string]bool{"x-data-id": true, "authorization": false}},
+		{[]Option{WithHeaderPrefix("header.")}, map[string]bool{"header.x-data-id": false, "header.authorization": false, "x-data-id": false}},
+		{[]Option{WithHeaderPrefix("header."), WithHeaders("x-data-id")}, map[string]bool{"header.x-data-id": true, "header.authorization": false}},
+		{[]Option{WithLegacyHeaders()}, map[string]bool{"x-data-id": true, "authorization": true}},
+	}
+
+	for i, cs := range cases {
+		c := newTestContext("GET", "/query?name=课件", "", nil)
+		c.Request.Header.Add("X-Data-Id", "1")
+		c.Request.Header.Add("Authorization", "Bearer token")
+
+		res, _, err := BindQueryMap(c, multiRules[:1], cs.opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for k, exist := range cs.keys {
+			if _, ok := res[k]; ok != exist {
+				t.Errorf("case %d header %s exist: %v", i, k, ok)
+			}
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package ginvalidate
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hetiansu5/urlquery"
+	"github.com/mitchellh/mapstructure"
+)
+
+func TestPathParams(t *testing.T) {
+
+	s1 := map[string]interface{}{
+		"name":     "query",
+		"ids":      "1,2,3",
+		"grade":    2,
+		"subjects": []int{3, 4, 12},
+		"ctime":    time.Now().Format("2006-01-02 15:04:05"),
+		"email":    "liumurong1@tal.com",
+		"phone":    "15810562936",
+		"stat":     3,
+		"school":   1,
+		"cname":    []string{"a", "b", "c"},
+	}
+
+	str, _ := urlquery.Marshal(s1)
+
+	req := httptest.NewRequest("POST", "/path/slide?"+string(str), nil)
+
+	w := httptest.NewRecorder()
+	// 调用相应的handler接口
+	router.ServeHTTP(w, req)
+
+	if w.Code != 200 {
+		t.Errorf("code error: %d", w.Code)
+	}
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	body, _ := ioutil.ReadAll(res.Body)
+
+	var resp Resp
+	json.Unmarshal(body, &resp)
+	var out SlideResp
+	mapstructure.Decode(resp.Data, &out)
+	if out.Name != "slide" {
+		t.Error("path params precedence error")
+	}
+
+}
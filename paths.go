@@ -0,0 +1,229 @@
+package ginvalidate
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rumis/govalidate/validator"
+)
+
+// PathSeparator 校验规则KEY中嵌套参数的分隔符，如 address.zip、items.*.qty
+const PathSeparator = "."
+
+// PathWildcard 匹配切片全部元素或map全部KEY的路径段
+const PathWildcard = "*"
+
+// defaultMaxPathExpansion 默认的通配符展开路径数量上限
+const defaultMaxPathExpansion = 10000
+
+// isPathKey 判断规则KEY是否为嵌套参数的路径
+// 参数中存在同名KEY，或KEY以 WithHeaderPrefix 的前缀开头时，按普通KEY处理
+func isPathKey(params map[string]interface{}, key string, o *options) bool {
+	if !strings.Contains(key, PathSeparator) {
+		return false
+	}
+	if o.headerPrefix != "" && strings.HasPrefix(key, o.headerPrefix) {
+		return false
+	}
+	_, ok := params[key]
+	return !ok
+}
+
+// expandRules 将路径中的通配符展开为具体的路径，如 items.*.qty 展开为 items.0.qty、items.1.qty
+// 展开的路径总数超出 WithMaxPathExpansion 时返回 *PayloadTooLargeError
+func expandRules(params map[string]interface{}, rules []validator.Filter, o *options) ([]validator.Filter, error) {
+	var res []validator.Filter
+	n := 0
+	unlimited := o.maxExpansion <= 0
+	for i, f := range rules {
+		if !strings.Contains(f.Key, PathWildcard) || !isPathKey(params, f.Key, o) {
+			if res != nil {
+				res = append(res, f)
+			}
+			continue
+		}
+		if res == nil {
+			res = append(make([]validator.Filter, 0, len(rules)), rules[:i]...)
+		}
+		segs := strings.Split(f.Key, PathSeparator)
+		var paths []string
+		if !expandPath(params[segs[0]], segs[1:], segs[0], &paths, o.maxExpansion-n, unlimited) {
+			return nil, &PayloadTooLargeError{Limit: int64(o.maxExpansion), Kind: "paths"}
+		}
+		n += len(paths)
+		for _, p := range paths {
+			// 与原规则共用校验器切片，以此找到 WithFilters 的校验器名称
+			res = append(res, validator.Filter{Key: p, Validators: f.Validators})
+		}
+	}
+	if res == nil {
+		return rules, nil
+	}
+	return res, nil
+}
+
+// expandPath 展开node下的路径并追加到res，prefix为已展开的部分
+// unlimited为false时展开的路径数量不能超过limit，超出时返回false
+func expandPath(node interface{}, segs []string, prefix string, res *[]string, limit int, unlimited bool) bool {
+	if len(segs) == 0 {
+		*res = append(*res, prefix)
+		return unlimited || len(*res) <= limit
+	}
+	seg, rest := segs[0], segs[1:]
+	if seg != PathWildcard {
+		child, _ := pathChild(node, seg)
+		return expandPath(child, rest, prefix+PathSeparator+seg, res, limit, unlimited)
+	}
+	switch tv := node.(type) {
+	case []interface{}:
+		for i, ev := range tv {
+			if !expandPath(ev, rest, prefix+PathSeparator+strconv.Itoa(i), res, limit, unlimited) {
+				return false
+			}
+		}
+	case map[string]interface{}:
+		keys := make([]string, 0, len(tv))
+		for k := range tv {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if !expandPath(tv[k], rest, prefix+PathSeparator+k, res, limit, unlimited) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// pathChild 获取map或切片的子元素
+func pathChild(node interface{}, seg string) (interface{}, bool) {
+	switch tv := node.(type) {
+	case map[string]interface{}:
+		v, ok := tv[seg]
+		return v, ok
+	case []interface{}:
+		if i, err := strconv.Atoi(seg); err == nil && i >= 0 && i < len(tv) {
+			return tv[i], true
+		}
+	case []string:
+		if i, err := strconv.Atoi(seg); err == nil && i >= 0 && i < len(tv) {
+			return tv[i], true
+		}
+	}
+	return nil, false
+}
+
+// lookupValue 获取规则KEY对应的参数值，支持嵌套参数的路径
+func lookupValue(params map[string]interface{}, key string, o *options) interface{} {
+	if !isPathKey(params, key, o) {
+		return params[key]
+	}
+	v, _ := getPath(params, strings.Split(key, PathSeparator))
+	return v
+}
+
+// getPath 获取路径对应的值
+func getPath(params map[string]interface{}, segs []string) (interface{}, bool) {
+	var node interface{} = params
+	for _, seg := range segs {
+		v, ok := pathChild(node, seg)
+		if !ok {
+			return nil, false
+		}
+		node = v
+	}
+	return node, true
+}
+
+// setPath 设置路径对应的值，路径中不存在的map自动创建
+func setPath(params map[string]interface{}, segs []string, value interface{}) {
+	var node interface{} = params
+	for i, seg := range segs {
+		last := i == len(segs)-1
+		switch tv := node.(type) {
+		case map[string]interface{}:
+			if last {
+				tv[seg] = value
+				return
+			}
+			child, ok := tv[seg]
+			if !ok || child == nil {
+				child = make(map[string]interface{})
+				tv[seg] = child
+			}
+			node = child
+		case []interface{}:
+			idx, err := strconv.Atoi(seg)
+			if err != nil || idx < 0 || idx >= len(tv) {
+				return
+			}
+			if last {
+				tv[idx] = value
+				return
+			}
+			if tv[idx] == nil {
+				tv[idx] = make(map[string]interface{})
+			}
+			node = tv[idx]
+		default:
+			return
+		}
+	}
+}
+
+// deletePath 删除路径对应的值，切片元素置为nil
+func deletePath(params map[string]interface{}, segs []string) {
+	parent, ok := getPath(params, segs[:len(segs)-1])
+	if !ok {
+		return
+	}
+	seg := segs[len(segs)-1]
+	switch tv := parent.(type) {
+	case map[string]interface{}:
+		delete(tv, seg)
+	case []interface{}:
+		if idx, err := strconv.Atoi(seg); err == nil && idx >= 0 && idx < len(tv) {
+			tv[idx] = nil
+		}
+	}
+}
+
+// copyValue 深拷贝嵌套的map与切片，写回校验结果时不修改原始参数
+func copyValue(v interface{}) interface{} {
+	switch tv := v.(type) {
+	case map[string]interface{}:
+		res := make(map[string]interface{}, len(tv))
+		for k, ev := range tv {
+			res[k] = copyValue(ev)
+		}
+		return res
+	case []interface{}:
+		res := make([]interface{}, len(tv))
+		for i, ev := range tv {
+			res[i] = copyValue(ev)
+		}
+		return res
+	}
+	return v
+}
+
+// writePath 将嵌套参数的校验结果写回res中相同的路径
+// ResetKey 的新KEY不含分隔符时为同级参数，否则为完整路径
+func writePath(res map[string]interface{}, key string, out map[string]interface{}) {
+	segs := strings.Split(key, PathSeparator)
+	if _, ok := out[key]; !ok {
+		deletePath(res, segs)
+	}
+	for k, v := range out {
+		switch {
+		case k == key:
+			setPath(res, segs, v)
+		case strings.Contains(k, PathSeparator):
+			setPath(res, strings.Split(k, PathSeparator), v)
+		default:
+			setPath(res, append(segs[:len(segs)-1:len(segs)-1], k), v)
+		}
+	}
+}
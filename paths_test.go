@@ -0,0 +1,93 @@
+package ginvalidate
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	R "github.com/rumis/govalidate"
+	V "github.com/rumis/govalidate/validator"
+)
+
+var pathRules = []V.Filter{
+	R.NewFilter("address.zip", []V.Validator{V.Required()}),
+	R.NewFilter("address.floor", []V.Validator{V.Optional(1), V.Int()}),
+	R.NewFilter("items.*.qty", []V.Validator{V.Required(), V.Int(), V.Between(1, 10)}),
+	R.NewFilter("items.*.sku", []V.Validator{V.Optional(), V.ResetKey("code")}),
+}
+
+func newPathContext(body string) *gin.Context {
+	return newTestContext("POST", "/paths", "application/json", strings.NewReader(body))
+}
+
+func TestPathRules(t *testing.T) {
+
+	body := `{"address":{"zip":"100000"},"items":[{"qty":"1","sku":"a"},{"qty":2}]}`
+
+	var out struct {
+		Address struct {
+			Zip   string `json:"zip"`
+			Floor int    `json:"floor"`
+		} `json:"address"`
+		Items []struct {
+			Qty  int    `json:"qty"`
+			Code string `json:"code"`
+		} `json:"items"`
+	}
+	if _, err := BindJsonStruct(newPathContext(body), pathRules, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Address.Zip != "100000" || out.Address.Floor != 1 || len(out.Items) != 2 || out.Items[0].Qty != 1 || out.Items[0].Code != "a" {
+		t.Errorf("path rules decode error: %+v", out)
+	}
+
+	body = `{"address":{},"items":[{"qty":1},{"qty":2},{"qty":30}]}`
+	raw, _, err := BindJsonMap(newPathContext(body), pathRules, WithCollectAll())
+	var errs ValidationErrors
+	if !errors.As(err, &errs) || len(errs) != 2 || errs[0].Field != "address.zip" || errs[1].Field != "items.2.qty" {
+		t.Fatalf("path rules error: %v", err)
+	}
+	if errs[1].Index != 2 || errs[1].Value == nil {
+		t.Errorf("path rules error detail: %+v", errs[1])
+	}
+	if _, ok := raw["address"].(map[string]interface{})["floor"]; ok {
+		t.Error("raw params modified")
+	}
+
+	_, _, err = BindJsonMap(newPathContext(body), pathRules)
+	var vErr *ValidationError
+	if !errors.As(err, &vErr) || vErr.Field != "address.zip" {
+		t.Errorf("path rules first error: %v", err)
+	}
+}
+
+func TestPathRulesMissingRoot(t *testing.T) {
+
+	res, _, err := BindJsonMap(newPathContext(`{}`), []V.Filter{R.NewFilter("address.floor", []V.Validator{V.Optional(1), V.Int()})})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := res["address.floor"]; ok {
+		t.Errorf("default written at flat key: %v", res)
+	}
+	if address, _ := res["address"].(map[string]interface{}); address == nil || fmt.Sprint(address["floor"]) != "1" {
+		t.Errorf("default not written at nested path: %v", res)
+	}
+
+	body := `{"items":[{"qty":1},{"qty":2},{"qty":3}]}`
+	_, _, err = BindJsonMap(newPathContext(body), pathRules[2:], WithMaxPathExpansion(5))
+	var pErr *PayloadTooLargeError
+	if !errors.As(err, &pErr) || pErr.Kind != "paths" {
+		t.Errorf("path expansion limit error: %v", err)
+	}
+	// 第一条规则恰好用完上限时，第二条规则继续展开也超出上限
+	_, _, err = BindJsonMap(newPathContext(body), pathRules[2:], WithMaxPathExpansion(3))
+	if !errors.As(err, &pErr) || pErr.Limit != 3 {
+		t.Errorf("path expansion exhausted limit error: %v", err)
+	}
+	if _, _, err = BindJsonMap(newPathContext(body), pathRules[2:], WithMaxPathExpansion(6)); err != nil {
+		t.Errorf("path expansion within limit error: %v", err)
+	}
+}
@@ -0,0 +1,98 @@
+package ginvalidate
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/rumis/govalidate/validator"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// MIMEProtobuf protobuf请求体的Content-Type，同时支持 application/x-protobuf
+const MIMEProtobuf = "application/protobuf"
+
+// BindProto 解析protobuf请求体并校验参数
+// 参数KEY为字段的JSON名称，校验通过后将转换后的参数写回msg
+// Content-type:application/x-protobuf，或 application/json 格式的protobuf JSON
+func BindProto(c *gin.Context, rules []validator.Filter, msg proto.Message, opts ...Option) (int32, error) {
+	o := newOptions(opts)
+	body := limitBody(c, o)
+	replay := bufferBody(c, o)
+	params, err := collectProto(c, o, msg)
+	replay.restore(c)
+	if body != nil && body.err != nil {
+		return 0, body.err
+	}
+	if err != nil {
+		return 0, err
+	}
+	res, errCode, err := validate(c, params, rules, o)
+	if err != nil {
+		return errCode, err
+	}
+	if err = protoDecode(res, msg); err != nil {
+		return 0, &DecodeError{Err: err}
+	}
+	return errCode, nil
+}
+
+// collectProto 将请求体解析到msg，并以字段的JSON名称转为map
+func collectProto(c *gin.Context, o *options, msg proto.Message) (map[string]interface{}, error) {
+	defer c.Request.Body.Close()
+	data, err := ioutil.ReadAll(c.Request.Body)
+	if err != nil {
+		return nil, &ParseError{Source: SourceProtobuf, Err: err}
+	}
+	ct := c.ContentType()
+	switch {
+	case ct == binding.MIMEPROTOBUF || ct == MIMEProtobuf:
+		err = proto.Unmarshal(data, msg)
+	case ct == binding.MIMEJSON || strings.HasSuffix(ct, "+json"):
+		if len(data) == 0 {
+			proto.Reset(msg)
+			break
+		}
+		err = protojson.Unmarshal(data, msg)
+	default:
+		return nil, &UnsupportedMediaTypeError{ContentType: ct}
+	}
+	if err != nil {
+		return nil, &ParseError{Source: SourceProtobuf, Err: err}
+	}
+	// 未设置的字段不输出，Required、Optional 对其生效
+	jsonData, err := protojson.Marshal(msg)
+	if err != nil {
+		return nil, &ParseError{Source: SourceProtobuf, Err: err}
+	}
+	tmpRes, err := decodeJSON(jsonData)
+	if err != nil {
+		return nil, &ParseError{Source: SourceProtobuf, Err: err}
+	}
+	// 合并路径参数与header参数
+	mergeParams(c, tmpRes, o)
+	return tmpRes, nil
+}
+
+// protoDecode 将校验后的参数写回msg，msg中没有的KEY被忽略
+func protoDecode(res map[string]interface{}, msg proto.Message) error {
+	data, err := json.Marshal(res)
+	if err != nil {
+		return err
+	}
+	proto.Reset(msg)
+	return protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(data, msg)
+}
+
+// decodeJSON 以 json.Number 解析数字
+func decodeJSON(data []byte) (map[string]interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	res := make(map[string]interface{})
+	err := decoder.Decode(&res)
+	return res, err
+}
@@ -0,0 +1,46 @@
+package ginvalidate
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	R "github.com/rumis/govalidate"
+	V "github.com/rumis/govalidate/validator"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/apipb"
+)
+
+var protoRules = []V.Filter{
+	R.NewFilter("name", []V.Validator{V.Required()}),
+	R.NewFilter("requestTypeUrl", []V.Validator{V.Optional("type.googleapis.com/Empty")}),
+}
+
+func TestBindProto(t *testing.T) {
+
+	data, _ := proto.Marshal(&apipb.Method{Name: "List", ResponseStreaming: true})
+
+	c := newTestContext("POST", "/proto", "application/x-protobuf", bytes.NewReader(data))
+
+	var msg apipb.Method
+	if _, err := BindProto(c, protoRules, &msg); err != nil {
+		t.Fatal(err)
+	}
+	if msg.Name != "List" || !msg.ResponseStreaming || msg.RequestTypeUrl != "type.googleapis.com/Empty" {
+		t.Errorf("bind proto error: %v", &msg)
+	}
+
+	c = newTestContext("POST", "/proto", "application/json", bytes.NewReader([]byte(`{"requestTypeUrl":"x"}`)))
+	_, err := BindProto(c, protoRules, &msg)
+	var vErr *ValidationError
+	if !errors.As(err, &vErr) || vErr.Field != "name" {
+		t.Errorf("proto validation error: %v", err)
+	}
+
+	c = newTestContext("POST", "/proto", "application/x-protobuf", bytes.NewReader([]byte{0xff}))
+	_, err = BindProto(c, protoRules, &msg)
+	var pErr *ParseError
+	if !errors.As(err, &pErr) || pErr.Source != SourceProtobuf {
+		t.Errorf("proto parse error: %v", err)
+	}
+}
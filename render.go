@@ -0,0 +1,138 @@
+package ginvalidate
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MIMEProblemJSON RFC 7807 错误响应的Content-Type
+const MIMEProblemJSON = "application/problem+json"
+
+// Renderer 将校验失败的结果写入gin.Context
+type Renderer interface {
+	Render(c *gin.Context, errCode int32, err error)
+}
+
+// ErrorHandler 函数形式的Renderer
+type ErrorHandler func(c *gin.Context, errCode int32, err error)
+
+// Render 实现Renderer
+func (h ErrorHandler) Render(c *gin.Context, errCode int32, err error) {
+	h(c, errCode, err)
+}
+
+// RenderError 使用配置的Renderer输出校验失败的结果并中止请求
+func RenderError(c *gin.Context, errCode int32, err error, opts ...Option) {
+	o := newOptions(opts)
+	c.Abort()
+	o.renderer.Render(c, errCode, err)
+}
+
+// ErrorStatus 获取错误对应的HTTP状态码，默认为400
+func ErrorStatus(err error) int {
+	var sErr interface{ StatusCode() int }
+	if errors.As(err, &sErr) {
+		return sErr.StatusCode()
+	}
+	return http.StatusBadRequest
+}
+
+// EnvelopeRenderer 输出 {code,msg,data} 格式的JSON
+type EnvelopeRenderer struct {
+	// Data 返回的data字段，为空时返回 {}
+	Data func(c *gin.Context, err error) interface{}
+}
+
+// Render 实现Renderer
+func (r EnvelopeRenderer) Render(c *gin.Context, errCode int32, err error) {
+	var data interface{} = gin.H{}
+	if r.Data != nil {
+		data = r.Data(c, err)
+	}
+	c.AbortWithStatusJSON(ErrorStatus(err), gin.H{
+		"code": errCode,
+		"msg":  err.Error(),
+		"data": data,
+	})
+}
+
+// ProblemRenderer 输出 RFC 7807 application/problem+json 格式的错误
+type ProblemRenderer struct {
+	// Type 问题类型URI，为空时为 about:blank
+	Type string
+}
+
+// InvalidParam problem+json 中 invalid-params 扩展字段的元素
+type InvalidParam struct {
+	Name   string `json:"name"`
+	Rule   string `json:"rule,omitempty"`
+	Code   int32  `json:"code"`
+	Reason string `json:"reason"`
+}
+
+// Problem RFC 7807 错误响应
+type Problem struct {
+	Type          string         `json:"type"`
+	Title         string         `json:"title"`
+	Status        int            `json:"status"`
+	Detail        string         `json:"detail"`
+	Instance      string         `json:"instance,omitempty"`
+	Code          int32          `json:"code"`
+	InvalidParams []InvalidParam `json:"invalid-params,omitempty"`
+}
+
+// Render 实现Renderer
+func (r ProblemRenderer) Render(c *gin.Context, errCode int32, err error) {
+	status := ErrorStatus(err)
+	p := Problem{
+		Type:     r.Type,
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   err.Error(),
+		Instance: c.Request.URL.Path,
+		Code:     errCode,
+	}
+	if p.Type == "" {
+		p.Type = "about:blank"
+	}
+	var errs ValidationErrors
+	var vErr *ValidationError
+	if errors.As(err, &errs) {
+		for _, e := range errs {
+			p.InvalidParams = append(p.InvalidParams, InvalidParam{Name: e.Field, Rule: e.Rule, Code: e.Code, Reason: e.Message})
+		}
+	} else if errors.As(err, &vErr) {
+		p.InvalidParams = []InvalidParam{{Name: vErr.Field, Rule: vErr.Rule, Code: vErr.Code, Reason: vErr.Message}}
+	}
+	c.Abort()
+	c.Render(status, problemJSON{data: p})
+}
+
+// TextRenderer 以纯文本输出错误信息
+type TextRenderer struct{}
+
+// Render 实现Renderer
+func (TextRenderer) Render(c *gin.Context, errCode int32, err error) {
+	c.Abort()
+	c.String(ErrorStatus(err), err.Error())
+}
+
+// problemJSON 以 application/problem+json 输出JSON
+type problemJSON struct {
+	data interface{}
+}
+
+func (r problemJSON) Render(w http.ResponseWriter) error {
+	r.WriteContentType(w)
+	return json.NewEncoder(w).Encode(r.data)
+}
+
+func (r problemJSON) WriteContentType(w http.ResponseWriter) {
+	header := w.Header()
+	if val := header["Content-Type"]; len(val) == 0 {
+		header["Content-Type"] = []string{MIMEProblemJSON}
+	}
+}
@@ -0,0 +1,65 @@
+package ginvalidate
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRenderer(t *testing.T) {
+
+	vErr := &ValidationError{
+		Field:   "email",
+		Rule:    "Email",
+		Code:    1001,
+		Message: "email format error",
+		Err:     errors.New("email format error"),
+	}
+
+	// problem+json
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("POST", "/json", nil)
+	RenderError(c, vErr.Code, vErr, WithRenderer(ProblemRenderer{}))
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("problem status error: %d", w.Code)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != MIMEProblemJSON {
+		t.Errorf("problem content type error: %s", ct)
+	}
+	var p Problem
+	json.Unmarshal(w.Body.Bytes(), &p)
+	if len(p.InvalidParams) != 1 || p.InvalidParams[0].Name != "email" {
+		t.Errorf("problem invalid params error: %v", p.InvalidParams)
+	}
+	if !c.IsAborted() {
+		t.Error("context not aborted")
+	}
+
+	// {code,msg,data}
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("POST", "/json", nil)
+	RenderError(c, vErr.Code, vErr, WithRenderer(EnvelopeRenderer{}))
+
+	var resp Resp
+	json.Unmarshal(w.Body.Bytes(), &resp)
+	if resp.Code != 1001 || resp.Msg != "email format error" {
+		t.Errorf("envelope render error: %v", resp)
+	}
+
+	// text
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("POST", "/json", nil)
+	RenderError(c, 0, &UnsupportedMediaTypeError{ContentType: "text/xml"}, WithRenderer(TextRenderer{}))
+
+	if w.Code != http.StatusUnsupportedMediaType {
+		t.Errorf("text status error: %d", w.Code)
+	}
+}
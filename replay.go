@@ -0,0 +1,69 @@
+package ginvalidate
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+
+	"github.com/gin-gonic/gin"
+)
+
+// replayBody 缓存的请求体
+type replayBody struct {
+	data []byte
+}
+
+// errReader 读取时返回缓存请求体时发生的错误
+type errReader struct {
+	err error
+}
+
+func (r errReader) Read(p []byte) (int, error) {
+	return 0, r.err
+}
+
+// readCloser 以Reader读取，关闭原始的请求体
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// bufferBody 按 WithBodyReplay 的配置缓存请求体，未配置或超出上限时返回nil
+// 超出上限的请求体照常解析，但不能重复读取
+func bufferBody(c *gin.Context, o *options) *replayBody {
+	if o.replayBytes <= 0 || c.Request.Body == nil {
+		return nil
+	}
+	orig := c.Request.Body
+	data, err := ioutil.ReadAll(io.LimitReader(orig, o.replayBytes+1))
+	if err != nil {
+		c.Request.Body = readCloser{io.MultiReader(bytes.NewReader(data), errReader{err}), orig}
+		return nil
+	}
+	if int64(len(data)) > o.replayBytes {
+		c.Request.Body = readCloser{io.MultiReader(bytes.NewReader(data), orig), orig}
+		return nil
+	}
+	orig.Close()
+	c.Request.Body = ioutil.NopCloser(bytes.NewReader(data))
+	return &replayBody{data: data}
+}
+
+// restore 将请求体恢复为可重新读取的内容，并保存在gin.Context中
+func (rb *replayBody) restore(c *gin.Context) {
+	if rb == nil {
+		return
+	}
+	c.Request.Body = ioutil.NopCloser(bytes.NewReader(rb.data))
+	c.Set(BodyKey, rb.data)
+}
+
+// GetBody 获取 WithBodyReplay 缓存的原始请求体
+func GetBody(c *gin.Context) ([]byte, bool) {
+	v, ok := c.Get(BodyKey)
+	if !ok {
+		return nil, false
+	}
+	data, ok := v.([]byte)
+	return data, ok
+}
@@ -0,0 +1,43 @@
+package ginvalidate
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestBodyReplay(t *testing.T) {
+
+	body := `{"name":"课件","ids":"1,2,3"}`
+
+	r := gin.New()
+	r.POST("/replay", Middleware(multiRules, WithBodyReplay(1024)), func(c *gin.Context) {
+		data, _ := ioutil.ReadAll(c.Request.Body)
+		raw, ok := GetBody(c)
+		if !ok || string(raw) != string(data) {
+			c.String(http.StatusInternalServerError, "body not replayed")
+			return
+		}
+		c.String(http.StatusOK, string(data))
+	})
+
+	req := httptest.NewRequest("POST", "/replay", strings.NewReader(body))
+	req.Header.Add("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusOK || w.Body.String() != body {
+		t.Errorf("body replay error: %d %s", w.Code, w.Body.String())
+	}
+
+	c := newTestContext("POST", "/replay", "application/json", strings.NewReader(body))
+	if _, _, err := BindJsonMap(c, multiRules, WithBodyReplay(8)); err != nil {
+		t.Fatalf("body over replay limit error: %v", err)
+	}
+	if _, ok := GetBody(c); ok {
+		t.Error("body over replay limit should not be buffered")
+	}
+}
@@ -0,0 +1,134 @@
+package ginvalidate
+
+import (
+	"fmt"
+
+	"github.com/rumis/govalidate/executor"
+	"github.com/rumis/govalidate/validator"
+)
+
+// Rule 带名称与参数的校验器
+// 名称用于 ValidationError.Rule，名称与参数用于生成OpenAPI文档
+type Rule struct {
+	Name      string
+	Args      []string
+	Validator validator.Validator
+}
+
+// NewRule 创建自定义的校验器，如 NewRule("Even", even)
+func NewRule(name string, v validator.Validator, args ...interface{}) Rule {
+	return Rule{Name: name, Args: ruleArgs(args), Validator: v}
+}
+
+// Filter 带校验器名称与参数的校验规则，通过 WithFilters 使用
+type Filter struct {
+	Key   string
+	Rules []Rule
+}
+
+// NewFilter 创建带校验器名称与参数的校验规则
+func NewFilter(key string, rules ...Rule) Filter {
+	return Filter{Key: key, Rules: rules}
+}
+
+// To 转为govalidate的校验规则
+func (f Filter) To() validator.Filter {
+	validators := make([]validator.Validator, len(f.Rules))
+	for i, r := range f.Rules {
+		validators[i] = r.Validator
+	}
+	return validator.Filter{Key: f.Key, Validators: validators}
+}
+
+// Filters 将带名称的校验规则转为govalidate的校验规则
+func Filters(fs ...Filter) []validator.Filter {
+	rules := make([]validator.Filter, 0, len(fs))
+	for _, f := range fs {
+		rules = append(rules, f.To())
+	}
+	return rules
+}
+
+// ruleArgs 将校验器参数转为字符串
+func ruleArgs(args []interface{}) []string {
+	if len(args) == 0 {
+		return nil
+	}
+	res := make([]string, 0, len(args))
+	for _, a := range args {
+		res = append(res, fmt.Sprint(a))
+	}
+	return res
+}
+
+// Required 必填
+func Required() Rule {
+	return NewRule("Required", validator.Required())
+}
+
+// Optional 选填，未传入时使用默认值
+func Optional(def ...interface{}) Rule {
+	return NewRule("Optional", validator.Optional(def...), def...)
+}
+
+// Int 整数
+func Int() Rule {
+	return NewRule("Int", validator.Int())
+}
+
+// Between 整数范围
+func Between(min, max int) Rule {
+	return NewRule("Between", validator.Between(min, max), min, max)
+}
+
+// EnumInt 整数枚举
+func EnumInt(enum ...int) Rule {
+	args := make([]interface{}, 0, len(enum))
+	for _, e := range enum {
+		args = append(args, e)
+	}
+	return NewRule("EnumInt", validator.EnumInt(enum), args...)
+}
+
+// DotInt 逗号分隔的整数，如 1,2,3
+func DotInt() Rule {
+	return NewRule("DotInt", validator.DotInt())
+}
+
+// Dotint2Slice 逗号分隔的整数转为整数切片
+func Dotint2Slice() Rule {
+	return NewRule("Dotint2Slice", validator.Dotint2Slice())
+}
+
+// IntSlice 整数切片
+func IntSlice(execs ...executor.IntExecutor) Rule {
+	if len(execs) == 0 {
+		return NewRule("IntSlice", validator.IntSlice())
+	}
+	return NewRule("IntSlice", validator.IntSlice(execs))
+}
+
+// StringSlice 字符串切片
+func StringSlice() Rule {
+	return NewRule("StringSlice", validator.StringSlice())
+}
+
+// Datetime 时间，格式为 2006-01-02 15:04:05
+func Datetime() Rule {
+	return NewRule("Datetime", validator.Datetime())
+}
+
+// Email 邮箱
+func Email() Rule {
+	return NewRule("Email", validator.Email())
+}
+
+// Phone 手机号
+func Phone() Rule {
+	return NewRule("Phone", validator.Phone())
+}
+
+// ResetKey 校验通过后将参数KEY改为key
+func ResetKey(key string) Rule {
+	return NewRule("ResetKey", validator.ResetKey(key), key)
+}
@@ -0,0 +1,105 @@
+package ginvalidate
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rumis/govalidate/validator"
+)
+
+// MIMENDJSON 以换行分隔的JSON请求体的Content-Type
+const MIMENDJSON = "application/x-ndjson"
+
+// defaultMaxLineBytes NDJSON单行的默认最大字节数
+const defaultMaxLineBytes = 1 << 20
+
+// Record NDJSON中单行的解析与校验结果
+type Record struct {
+	Line   int                    // 行号，从1开始
+	Params map[string]interface{} // 校验通过的参数，失败时为原始参数
+	Code   int32                  // 校验失败的错误码
+	Err    error                  // 解析失败时为 *ParseError，校验失败时为 *ValidationError
+}
+
+// RecordHandler 处理单行记录，返回错误时停止读取
+type RecordHandler func(r Record) error
+
+// StreamJson 逐行读取NDJSON请求体，校验后交由fn处理，不缓存整个请求体
+// 单行解析或校验失败不会中止读取；请求的context取消、fn返回错误或单行超出 WithMaxLineBytes 时中止
+// Content-type:application/x-ndjson
+func StreamJson(c *gin.Context, rules []validator.Filter, fn RecordHandler, opts ...Option) error {
+	o := newOptions(opts)
+	body := limitBody(c, o)
+	err := streamJSON(c, rules, fn, o)
+	if body != nil && body.err != nil {
+		return body.err
+	}
+	return err
+}
+
+// StreamJsonChan 逐行读取NDJSON请求体，校验后发送到ch，返回前关闭ch
+// 接收方处理不及时时暂停读取，ch的缓冲大小决定读取的超前量
+// Content-type:application/x-ndjson
+func StreamJsonChan(c *gin.Context, rules []validator.Filter, ch chan<- Record, opts ...Option) error {
+	defer close(ch)
+	ctx := c.Request.Context()
+	return StreamJson(c, rules, func(r Record) error {
+		select {
+		case ch <- r:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}, opts...)
+}
+
+// streamJSON 逐行解析并校验
+func streamJSON(c *gin.Context, rules []validator.Filter, fn RecordHandler, o *options) error {
+	defer c.Request.Body.Close()
+	ctx := c.Request.Context()
+	maxLine := o.maxLineBytes
+	if maxLine <= 0 {
+		maxLine = defaultMaxLineBytes
+	}
+	bufSize := 4096
+	if maxLine < bufSize {
+		bufSize = maxLine
+	}
+	scanner := bufio.NewScanner(c.Request.Body)
+	scanner.Buffer(make([]byte, 0, bufSize), maxLine)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+		if err := fn(validateRecord(c, line, data, rules, o)); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return &ParseError{Source: SourceJSON, Err: fmt.Errorf("line %d: %w", line+1, err)}
+	}
+	return nil
+}
+
+// validateRecord 解析并校验单行记录
+func validateRecord(c *gin.Context, line int, data []byte, rules []validator.Filter, o *options) Record {
+	params, err := decodeJSON(data)
+	if err != nil {
+		return Record{Line: line, Err: &ParseError{Source: SourceJSON, Err: fmt.Errorf("line %d: %w", line, err)}}
+	}
+	// 合并路径参数与header参数
+	mergeParams(c, params, o)
+	res, errCode, err := validate(c, params, rules, o)
+	if err != nil {
+		return Record{Line: line, Params: params, Code: errCode, Err: err}
+	}
+	return Record{Line: line, Params: res, Code: errCode}
+}
@@ -0,0 +1,78 @@
+package ginvalidate
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+const ndjsonBody = `{"name":"a","ids":"1"}
+
+{"name":"b"}
+{"name":
+{"name":"d","ids":"4"}
+`
+
+func newStreamContext(body string) *gin.Context {
+	return newTestContext("POST", "/stream", MIMENDJSON, strings.NewReader(body))
+}
+
+func TestStreamJson(t *testing.T) {
+
+	var records []Record
+	err := StreamJson(newStreamContext(ndjsonBody), multiRules, func(r Record) error {
+		records = append(records, r)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 4 {
+		t.Fatalf("records count error: %d", len(records))
+	}
+	var vErr *ValidationError
+	var pErr *ParseError
+	if records[0].Err != nil || records[0].Params["name"] != "a" || records[3].Line != 5 {
+		t.Errorf("valid record error: %v %v", records[0], records[3])
+	}
+	if !errors.As(records[1].Err, &vErr) || vErr.Field != "ids" || records[1].Line != 3 {
+		t.Errorf("invalid record error: %v", records[1])
+	}
+	if !errors.As(records[2].Err, &pErr) {
+		t.Errorf("malformed record error: %v", records[2])
+	}
+
+	err = StreamJson(newStreamContext(ndjsonBody), multiRules, func(r Record) error {
+		return nil
+	}, WithMaxLineBytes(16))
+	if !errors.As(err, &pErr) {
+		t.Errorf("line limit error: %v", err)
+	}
+}
+
+func TestStreamJsonChan(t *testing.T) {
+
+	c := newStreamContext(ndjsonBody)
+	ctx, cancel := context.WithCancel(context.Background())
+	c.Request = c.Request.WithContext(ctx)
+
+	ch := make(chan Record)
+	done := make(chan error)
+	go func() {
+		done <- StreamJsonChan(c, multiRules, ch)
+	}()
+
+	r := <-ch
+	if r.Line != 1 || r.Err != nil {
+		t.Errorf("first record error: %v", r)
+	}
+	cancel()
+	for range ch {
+	}
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("stream cancel error: %v", err)
+	}
+}
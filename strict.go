@@ -0,0 +1,108 @@
+package ginvalidate
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rumis/govalidate/validator"
+)
+
+// ErrCodeUnknownField WithStrict 时参数中存在校验规则未声明的KEY的错误码
+const ErrCodeUnknownField int32 = 4100
+
+// validateUnknown WithStrict 时检查body与query中校验规则未声明的参数
+// 嵌套的map与切片逐层检查，如规则 address.zip 不声明 address.city；路径参数与header参数不检查
+func validateUnknown(c *gin.Context, params map[string]interface{}, rules []validator.Filter, o *options) ValidationErrors {
+	if !o.strict {
+		return nil
+	}
+	known := make(map[string]bool, len(rules)+len(c.Params))
+	rulePaths := make([][]string, 0, len(rules))
+	for _, f := range rules {
+		known[f.Key] = true
+		rulePaths = append(rulePaths, strings.Split(f.Key, PathSeparator))
+	}
+	for _, p := range c.Params {
+		known[p.Key] = true
+	}
+	for k := range c.Request.Header {
+		if key, ok := o.headerKey(k); ok {
+			known[key] = true
+		}
+	}
+	var unknown [][]string
+	for k, v := range params {
+		if !known[k] {
+			unknown = unknownPaths(v, []string{k}, rulePaths, unknown)
+		}
+	}
+	sort.Slice(unknown, func(i, j int) bool {
+		return strings.Join(unknown[i], PathSeparator) < strings.Join(unknown[j], PathSeparator)
+	})
+	var errs ValidationErrors
+	for _, segs := range unknown {
+		k := strings.Join(segs, PathSeparator)
+		v, _ := getPath(params, segs)
+		err := fmt.Errorf("%s: unknown field", k)
+		errs = append(errs, &ValidationError{
+			Field:   k,
+			Rule:    "Strict",
+			Index:   -1,
+			Value:   v,
+			Code:    ErrCodeUnknownField,
+			Message: err.Error(),
+			Err:     err,
+		})
+		if !o.collectAll {
+			break
+		}
+	}
+	return errs
+}
+
+// unknownPaths 将node下没有规则声明的参数路径追加到res
+// 规则声明了path或其上层路径时path下的参数均已声明，规则仅声明了path的下层路径时逐层检查
+func unknownPaths(node interface{}, path []string, rulePaths [][]string, res [][]string) [][]string {
+	nested := false
+	for _, rp := range rulePaths {
+		covered, prefix := matchRulePath(rp, path)
+		if covered {
+			return res
+		}
+		nested = nested || prefix
+	}
+	if !nested {
+		return append(res, path)
+	}
+	child := func(seg string) []string {
+		return append(path[:len(path):len(path)], seg)
+	}
+	switch tv := node.(type) {
+	case map[string]interface{}:
+		for k, ev := range tv {
+			res = unknownPaths(ev, child(k), rulePaths, res)
+		}
+	case []interface{}:
+		for i, ev := range tv {
+			res = unknownPaths(ev, child(strconv.Itoa(i)), rulePaths, res)
+		}
+	}
+	return res
+}
+
+// matchRulePath 比较规则路径与参数路径，规则路径中的通配符匹配任意路径段
+// covered表示规则声明了path或其上层路径，prefix表示规则声明了path的下层路径
+func matchRulePath(rule []string, path []string) (covered bool, prefix bool) {
+	for i, seg := range path {
+		if i == len(rule) {
+			return true, false
+		}
+		if rule[i] != seg && rule[i] != PathWildcard {
+			return false, false
+		}
+	}
+	return len(rule) == len(path), len(rule) > len(path)
+}
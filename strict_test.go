@@ -0,0 +1,60 @@
+package ginvalidate
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestStrict(t *testing.T) {
+
+	c := newTestContext("GET", "/query?name=课件&ids=1&admin=1&debug=1", "", nil)
+	c.Request.Header.Add("X-Data-Id", "1")
+
+	_, _, err := BindQueryMap(c, multiRules, WithStrict(), WithCollectAll(), WithHeaders("x-data-id"))
+	var errs ValidationErrors
+	if !errors.As(err, &errs) || len(errs) != 2 || errs[0].Field != "admin" || errs[1].Field != "debug" || errs[0].Code != ErrCodeUnknownField {
+		t.Fatalf("strict error: %v", err)
+	}
+
+	c = newTestContext("GET", "/query?name=课件&ids=1", "", nil)
+	c.Request.Header.Add("X-Data-Id", "1")
+	if _, _, err = BindQueryMap(c, multiRules, WithStrict(), WithHeaders("x-data-id")); err != nil {
+		t.Errorf("strict known fields error: %v", err)
+	}
+
+	// 嵌套参数逐层检查
+	body := `{"address":{"zip":"1","city":"a"},"items":[{"qty":1,"extra":1}],"debug":1}`
+	_, _, err = BindJsonMap(newPathContext(body), pathRules, WithStrict(), WithCollectAll())
+	if !errors.As(err, &errs) || len(errs) < 3 || errs[0].Field != "address.city" || errs[1].Field != "debug" || errs[2].Field != "items.0.extra" {
+		t.Fatalf("strict nested error: %v", err)
+	}
+	if _, _, err = BindJsonMap(newPathContext(`{"address":{"zip":"1"},"items":[{"qty":1,"sku":"a"}]}`), pathRules, WithStrict()); err != nil {
+		t.Errorf("strict nested known fields error: %v", err)
+	}
+}
+
+func TestErrorUnusedUnset(t *testing.T) {
+
+	newContext := func() *gin.Context {
+		return newTestContext("POST", "/json", "application/json", strings.NewReader(`{"name":"课件","ids":"1","extra":1}`))
+	}
+
+	var out struct {
+		Name  string `json:"name"`
+		Ids   string `json:"ids"`
+		Grade int    `json:"grade"`
+	}
+	var dErr *DecodeError
+	if _, err := BindJsonStruct(newContext(), multiRules, &out, WithErrorUnused()); !errors.As(err, &dErr) {
+		t.Errorf("error unused error: %v", err)
+	}
+	if _, err := BindJsonStruct(newContext(), multiRules, &out, WithErrorUnset()); !errors.As(err, &dErr) {
+		t.Errorf("error unset error: %v", err)
+	}
+	if _, err := BindJsonStruct(newContext(), multiRules, &out); err != nil {
+		t.Errorf("decode error: %v", err)
+	}
+}
@@ -0,0 +1,253 @@
+package ginvalidate
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rumis/govalidate/executor"
+	"github.com/rumis/govalidate/validator"
+)
+
+// TagName 校验规则使用的struct tag
+// 例：`json:"grade" validate:"required,int,between=1|100"`
+const TagName = "validate"
+
+// tagRule 单个校验器的tag定义，如 between=1|100
+type tagRule struct {
+	Name string
+	Args []string
+}
+
+// fieldRules 单个字段的tag定义
+type fieldRules struct {
+	Key   string
+	Field reflect.StructField
+	Rules []tagRule
+}
+
+// tagBuilder 根据tag参数构造校验器
+type tagBuilder func(f reflect.StructField, args []string) (Rule, error)
+
+// tagBuilders tag名称与校验器的对应关系
+var tagBuilders = map[string]tagBuilder{
+	"required":     noArgs(Required),
+	"int":          noArgs(Int),
+	"dotint":       noArgs(DotInt),
+	"dotint2slice": noArgs(Dotint2Slice),
+	"datetime":     noArgs(Datetime),
+	"email":        noArgs(Email),
+	"phone":        noArgs(Phone),
+	"stringslice":  noArgs(StringSlice),
+	"intslice": func(f reflect.StructField, args []string) (Rule, error) {
+		if len(args) == 0 {
+			return IntSlice(), nil
+		}
+		// intslice=between=1|100 对每个元素执行 executor.Between(1, 100)
+		exec, err := parseIntExecutor(strings.Join(args, "|"))
+		if err != nil {
+			return Rule{}, err
+		}
+		r := IntSlice(exec)
+		r.Args = args
+		return r, nil
+	},
+	"optional": func(f reflect.StructField, args []string) (Rule, error) {
+		if len(args) == 0 {
+			return Optional(), nil
+		}
+		def, err := parseDefault(f.Type, args[0])
+		if err != nil {
+			return Rule{}, err
+		}
+		return Optional(def), nil
+	},
+	"between": func(f reflect.StructField, args []string) (Rule, error) {
+		if len(args) != 2 {
+			return Rule{}, fmt.Errorf("between requires 2 args, got %d", len(args))
+		}
+		ints, err := parseInts(args)
+		if err != nil {
+			return Rule{}, err
+		}
+		return Between(ints[0], ints[1]), nil
+	},
+	"enumint": func(f reflect.StructField, args []string) (Rule, error) {
+		if len(args) == 0 {
+			return Rule{}, fmt.Errorf("enumint requires args")
+		}
+		ints, err := parseInts(args)
+		if err != nil {
+			return Rule{}, err
+		}
+		return EnumInt(ints...), nil
+	},
+	"resetkey": func(f reflect.StructField, args []string) (Rule, error) {
+		if len(args) != 1 {
+			return Rule{}, fmt.Errorf("resetkey requires 1 arg, got %d", len(args))
+		}
+		return ResetKey(args[0]), nil
+	},
+}
+
+// rulesCache 已解析的校验规则
+var rulesCache sync.Map
+
+// RulesFor 根据对象的 validate tag 生成校验规则
+// 参数KEY取json tag，未设置时取字段名；未设置 validate tag 的字段不参与校验
+func RulesFor(obj interface{}) ([]validator.Filter, error) {
+	fs, err := filtersFor(obj)
+	if err != nil {
+		return nil, err
+	}
+	return Filters(fs...), nil
+}
+
+// filtersFor 根据对象的 validate tag 生成带校验器名称与参数的校验规则
+func filtersFor(obj interface{}) ([]Filter, error) {
+	t := reflect.TypeOf(obj)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("RulesFor requires a struct, got %T", obj)
+	}
+	if v, ok := rulesCache.Load(t); ok {
+		return v.([]Filter), nil
+	}
+	fields, err := parseTags(t)
+	if err != nil {
+		return nil, err
+	}
+	fs := make([]Filter, 0, len(fields))
+	for _, fr := range fields {
+		f := Filter{Key: fr.Key, Rules: make([]Rule, 0, len(fr.Rules))}
+		for _, r := range fr.Rules {
+			v, err := tagBuilders[r.Name](fr.Field, r.Args)
+			if err != nil {
+				return nil, fmt.Errorf("field %s tag %s: %w", fr.Field.Name, r.Name, err)
+			}
+			f.Rules = append(f.Rules, v)
+		}
+		fs = append(fs, f)
+	}
+	rulesCache.Store(t, fs)
+	return fs, nil
+}
+
+// BindStructAuto 根据对象的 validate tag 解析并校验参数
+// 校验失败时 ValidationError.Rule 为校验器名称
+func BindStructAuto(c *gin.Context, obj interface{}, opts ...Option) (int32, error) {
+	fs, err := filtersFor(obj)
+	if err != nil {
+		return 0, err
+	}
+	return BindStruct(c, nil, obj, withOptions(opts, WithFilters(fs...))...)
+}
+
+// parseTags 解析结构体的 validate tag
+func parseTags(t reflect.Type) ([]fieldRules, error) {
+	var fields []fieldRules
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag, ok := f.Tag.Lookup(TagName)
+		if !ok || tag == "" || tag == "-" {
+			continue
+		}
+		key := fieldKey(f)
+		if key == "" {
+			continue
+		}
+		fr := fieldRules{Key: key, Field: f}
+		for _, item := range strings.Split(tag, ",") {
+			item = strings.TrimSpace(item)
+			if item == "" {
+				continue
+			}
+			r := tagRule{Name: item}
+			if i := strings.Index(item, "="); i >= 0 {
+				r.Name = item[:i]
+				r.Args = strings.Split(item[i+1:], "|")
+			}
+			r.Name = strings.ToLower(r.Name)
+			if _, ok := tagBuilders[r.Name]; !ok {
+				return nil, fmt.Errorf("field %s: unknown validate tag %q", f.Name, r.Name)
+			}
+			fr.Rules = append(fr.Rules, r)
+		}
+		fields = append(fields, fr)
+	}
+	return fields, nil
+}
+
+// fieldKey 字段对应的参数KEY，与mapDecode使用的json tag保持一致
+func fieldKey(f reflect.StructField) string {
+	name := strings.Split(f.Tag.Get("json"), ",")[0]
+	if name == "-" {
+		return ""
+	}
+	if name == "" {
+		return f.Name
+	}
+	return name
+}
+
+// noArgs 无参数的校验器
+func noArgs(fn func() Rule) tagBuilder {
+	return func(f reflect.StructField, args []string) (Rule, error) {
+		if len(args) > 0 {
+			return Rule{}, fmt.Errorf("unexpected args %v", args)
+		}
+		return fn(), nil
+	}
+}
+
+// parseIntExecutor 解析 intslice 的元素校验，如 between=1|100
+func parseIntExecutor(s string) (executor.IntExecutor, error) {
+	name, arg := s, ""
+	if i := strings.Index(s, "="); i >= 0 {
+		name, arg = s[:i], s[i+1:]
+	}
+	switch strings.ToLower(name) {
+	case "between":
+		ints, err := parseInts(strings.Split(arg, "|"))
+		if err != nil {
+			return nil, err
+		}
+		if len(ints) != 2 {
+			return nil, fmt.Errorf("intslice between requires 2 args, got %d", len(ints))
+		}
+		return executor.Between(ints[0], ints[1]), nil
+	}
+	return nil, fmt.Errorf("unknown intslice executor %q", name)
+}
+
+// parseInts 解析整数参数
+func parseInts(args []string) ([]int, error) {
+	res := make([]int, 0, len(args))
+	for _, a := range args {
+		i, err := strconv.Atoi(strings.TrimSpace(a))
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, i)
+	}
+	return res, nil
+}
+
+// parseDefault 按字段类型解析 optional 的默认值
+func parseDefault(t reflect.Type, s string) (interface{}, error) {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.Atoi(s)
+	case reflect.Float32, reflect.Float64:
+		return strconv.ParseFloat(s, 64)
+	case reflect.Bool:
+		return strconv.ParseBool(s)
+	}
+	return s, nil
+}
@@ -0,0 +1,86 @@
+package ginvalidate
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+type TagReq struct {
+	Name     string    `json:"name" validate:"required"`
+	Ids      []int     `json:"ids" validate:"required,dotint,dotint2slice"`
+	Grade    int       `json:"grade" validate:"required,int,between=1|100"`
+	Subjects []int     `json:"subjects" validate:"required,intslice=between=1|100"`
+	Ctime    time.Time `json:"ctime" validate:"required,datetime"`
+	Email    string    `json:"email" validate:"required,email"`
+	Stat     int       `json:"stat" validate:"required,enumint=1|2|3|4|5"`
+	Cname    []string  `json:"cname" validate:"required,stringslice"`
+	Page     int       `json:"page" validate:"optional=101,int"`
+	Remark   string    `json:"remark"`
+}
+
+func TestRulesFor(t *testing.T) {
+
+	fs, err := RulesFor(&TagReq{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fs) != 9 {
+		t.Errorf("rules count error: %d", len(fs))
+	}
+
+	type badReq struct {
+		Name string `json:"name" validate:"required,unknown"`
+	}
+	if _, err := RulesFor(badReq{}); err == nil {
+		t.Error("unknown tag should fail")
+	}
+
+	type badIntSliceReq struct {
+		Ids []int `json:"ids" validate:"intslice=min=1"`
+	}
+	type badStringSliceReq struct {
+		Cname []string `json:"cname" validate:"stringslice=1|2"`
+	}
+	for _, obj := range []interface{}{badIntSliceReq{}, badStringSliceReq{}} {
+		if _, err := RulesFor(obj); err == nil {
+			t.Errorf("unsupported slice tag args should fail: %T", obj)
+		}
+	}
+}
+
+func TestBindStructAuto(t *testing.T) {
+
+	s1 := map[string]interface{}{
+		"name":     "课件",
+		"ids":      "1,2,3",
+		"grade":    2,
+		"subjects": []int{3, 4, 12},
+		"ctime":    time.Now().Format("2006-01-02 15:04:05"),
+		"email":    "liumurong1@tal.com",
+		"stat":     3,
+		"cname":    []string{"a", "b", "c"},
+	}
+	s1Byte, _ := json.Marshal(s1)
+
+	c := newTestContext("POST", "/json", "application/json", bytes.NewReader(s1Byte))
+
+	var out TagReq
+	if _, err := BindStructAuto(c, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Name != "课件" || out.Ids[2] != 3 || out.Page != 101 {
+		t.Errorf("bind struct auto error: %v", out)
+	}
+
+	s1["subjects"] = []int{3, 400}
+	s1Byte, _ = json.Marshal(s1)
+	c = newTestContext("POST", "/json", "application/json", bytes.NewReader(s1Byte))
+	_, err := BindStructAuto(c, &out)
+	var vErr *ValidationError
+	if !errors.As(err, &vErr) || vErr.Field != "subjects" || vErr.Rule != "IntSlice" || vErr.Index != 1 {
+		t.Errorf("intslice between error: %v", err)
+	}
+}
@@ -7,14 +7,14 @@ import (
 )
 
 // mapDecode map转对象
-func mapDecode(input interface{}, out interface{}) error {
+func mapDecode(input interface{}, out interface{}, d DecoderOptions) error {
 	config := &mapstructure.DecoderConfig{
-		DecodeHook: mapstructure.ComposeDecodeHookFunc(
-			mapstructure.StringToTimeHookFunc("2006-01-02 15:04:05"),
-			mapstructure.StringToTimeHookFunc("2006-01-02")),
-		Metadata: nil,
-		Result:   out,
-		TagName:  "json",
+		DecodeHook:  mapstructure.ComposeDecodeHookFunc(d.hooks()...),
+		Metadata:    nil,
+		Result:      out,
+		TagName:     "json",
+		ErrorUnused: d.ErrorUnused,
+		ErrorUnset:  d.ErrorUnset,
 	}
 	decoder, err := mapstructure.NewDecoder(config)
 	if err != nil {
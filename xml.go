@@ -0,0 +1,100 @@
+package ginvalidate
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// XMLTextKey 元素同时包含属性或子元素与文本时，文本在map中的KEY
+const XMLTextKey = "#text"
+
+// collectXML 解析XML参数
+func collectXML(c *gin.Context, o *options) (map[string]interface{}, error) {
+	return collectBody(c, o, decodeXML)
+}
+
+// decodeXML 将XML文档转为map，根元素的属性与子元素为map的KEY
+// 属性与仅包含文本的元素为字符串，重复的元素为切片，空body返回空map
+func decodeXML(r io.Reader) (map[string]interface{}, error) {
+	decoder := xml.NewDecoder(r)
+	for {
+		tok, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return make(map[string]interface{}), nil
+		}
+		if err != nil {
+			return make(map[string]interface{}), err
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		v, err := xmlElement(decoder, start)
+		if err != nil {
+			return make(map[string]interface{}), err
+		}
+		if m, ok := v.(map[string]interface{}); ok {
+			return m, nil
+		}
+		res := make(map[string]interface{})
+		if s := v.(string); s != "" {
+			res[XMLTextKey] = s
+		}
+		return res, nil
+	}
+}
+
+// xmlElement 解析单个元素，start 为已读取的开始标签
+func xmlElement(decoder *xml.Decoder, start xml.StartElement) (interface{}, error) {
+	res := make(map[string]interface{})
+	for _, attr := range start.Attr {
+		if attr.Name.Space == "xmlns" || attr.Name.Local == "xmlns" {
+			continue
+		}
+		xmlSet(res, attr.Name.Local, attr.Value)
+	}
+	var text strings.Builder
+	for {
+		tok, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			v, err := xmlElement(decoder, t)
+			if err != nil {
+				return nil, err
+			}
+			xmlSet(res, t.Name.Local, v)
+		case xml.CharData:
+			text.Write(t)
+		case xml.EndElement:
+			s := strings.TrimSpace(text.String())
+			if len(res) == 0 {
+				return s, nil
+			}
+			if s != "" {
+				res[XMLTextKey] = s
+			}
+			return res, nil
+		}
+	}
+}
+
+// xmlSet 设置元素的值，同名元素重复出现时转为切片
+func xmlSet(m map[string]interface{}, k string, v interface{}) {
+	ev, ok := m[k]
+	if !ok {
+		m[k] = v
+		return
+	}
+	if s, ok := ev.([]interface{}); ok {
+		m[k] = append(s, v)
+		return
+	}
+	m[k] = []interface{}{ev, v}
+}
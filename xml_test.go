@@ -0,0 +1,69 @@
+package ginvalidate
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDecodeXML(t *testing.T) {
+
+	doc := `<?xml version="1.0"?>
+<req id="7">
+	<name>课件</name>
+	<ids>1,2,3</ids>
+	<cname>a</cname>
+	<cname>b</cname>
+	<school code="12">一中</school>
+	<empty/>
+</req>`
+
+	res, err := decodeXML(strings.NewReader(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{
+		"id":    "7",
+		"name":  "课件",
+		"ids":   "1,2,3",
+		"cname": []interface{}{"a", "b"},
+		"school": map[string]interface{}{
+			"code":     "12",
+			XMLTextKey: "一中",
+		},
+		"empty": "",
+	}
+	if !reflect.DeepEqual(res, want) {
+		t.Errorf("decode xml error: %v", res)
+	}
+
+	if _, err := decodeXML(strings.NewReader(`<req><name>`)); err == nil {
+		t.Error("malformed xml should fail")
+	}
+}
+
+func TestBindXml(t *testing.T) {
+
+	body := `<req><name>课件</name><ids>1,2,3</ids></req>`
+
+	c := newTestContext("POST", "/xml", "application/xml", strings.NewReader(body))
+
+	var out struct {
+		Name string `json:"name"`
+		Ids  string `json:"ids"`
+	}
+	if _, err := BindStruct(c, multiRules, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Name != "课件" || out.Ids != "1,2,3" {
+		t.Errorf("bind xml error: %v", out)
+	}
+
+	c = newTestContext("POST", "/xml", "text/xml", strings.NewReader(`<req><name>课件</name></req>`))
+	_, _, err := BindXmlMap(c, multiRules)
+	var vErr *ValidationError
+	if !errors.As(err, &vErr) || vErr.Field != "ids" {
+		t.Errorf("xml validation error: %v", err)
+	}
+}